@@ -0,0 +1,136 @@
+// path: parallel/batch.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the concurrency used by RunTasks when BatchOptions.Concurrency is zero.
+const DefaultBatchConcurrency = 8
+
+// ErrBatchAborted is reported for batch items that were skipped or abandoned
+// because another item failed in fail-fast mode.
+var ErrBatchAborted = errors.New("parallel: batch aborted")
+
+// BatchOptions configures RunTasks.
+type BatchOptions struct {
+	Concurrency int         // maximum number of tasks submitted and awaited at once
+	FailFast    bool        // after the first failure, skip pending items and abandon running ones
+	Poll        PollOptions // how each run is awaited; set Poll.CancelOnAbort to cancel abandoned runs
+}
+
+// BatchResult is the outcome of one request in a RunTasks batch.
+type BatchResult struct {
+	Index  int                 // position of the request in the input
+	RunID  string              // empty if the task was never submitted
+	Result *ParallelTaskResult // final state of the run, if it finished
+	Err    error               // nil on success; *TaskFailedError for unsuccessful runs
+}
+
+// BatchError is returned by RunTasks when at least one item failed.
+type BatchError struct {
+	Failed int
+	Total  int
+	Errors []error // errors of the failed items, in input order
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d tasks failed: %v", e.Failed, e.Total, e.Errors[0])
+}
+
+func (e *BatchError) Unwrap() []error { return e.Errors }
+
+// RunTasks submits reqs with at most opts.Concurrency in flight, waits for
+// each run to finish, and returns one BatchResult per request in input order.
+// If any item failed, the returned error is a *BatchError; per-item errors
+// are in the results. A run abandoned because ctx is done or another item
+// failed in fail-fast mode is cancelled when Poll.CancelOnAbort or
+// WithCancelOnAbort is set.
+func (c *Client) RunTasks(ctx context.Context, reqs []ParallelTaskRequest, opts BatchOptions) ([]BatchResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	bctx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	results := make([]BatchResult, len(reqs))
+	started := runBounded(bctx, len(reqs), concurrency, func(i int) {
+		results[i] = c.runBatchItem(bctx, i, reqs[i], opts.Poll)
+		if results[i].Err != nil && opts.FailFast {
+			abort(ErrBatchAborted)
+		}
+	})
+	for i := started; i < len(reqs); i++ {
+		results[i] = BatchResult{Index: i, Err: context.Cause(bctx)}
+	}
+
+	aborted := ctx.Err() == nil && errors.Is(context.Cause(bctx), ErrBatchAborted)
+	var batchErr BatchError
+	for i := range results {
+		if aborted && results[i].Err != nil && errors.Is(results[i].Err, context.Canceled) {
+			results[i].Err = ErrBatchAborted
+		}
+		if results[i].Err != nil {
+			batchErr.Errors = append(batchErr.Errors, results[i].Err)
+		}
+	}
+	if len(batchErr.Errors) == 0 {
+		return results, nil
+	}
+	batchErr.Failed, batchErr.Total = len(batchErr.Errors), len(reqs)
+	return results, &batchErr
+}
+
+// runBatchItem submits one request and waits for its run to finish.
+func (c *Client) runBatchItem(ctx context.Context, i int, req ParallelTaskRequest, poll PollOptions) BatchResult {
+	res := BatchResult{Index: i}
+
+	resp, err := c.RunTask(ctx, req)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.RunID = resp.Output.RunID
+
+	res.Result, res.Err = c.PollUntilCompleteWithOptions(ctx, res.RunID, poll)
+	var failed *TaskFailedError
+	if errors.As(res.Err, &failed) {
+		res.Result = failed.Result
+	}
+	return res
+}
+
+// runBounded calls fn for each index in [0, n), in order, on at most
+// concurrency goroutines, and waits for the calls to return. Once ctx is
+// done no further calls are started; it returns how many were.
+func runBounded(ctx context.Context, n, concurrency int, fn func(i int)) int {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	started := 0
+feed:
+	for ; started < n; started++ {
+		select {
+		case indexes <- started:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return started
+}
@@ -0,0 +1,112 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBatchServer creates runs named after their input; runs whose input
+// starts with "fail" end unsuccessfully, all others complete.
+func newBatchServer(t *testing.T, submitted *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/tasks/runs" {
+			submitted.Add(1)
+			var req ParallelTaskRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Expected a task request, got %v", err)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"output": map[string]any{"run_id": req.Input, "status": "queued"}})
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/tasks/runs/")
+		status := TaskStatusCompleted
+		if strings.HasPrefix(id, "fail") {
+			status = TaskStatusFailed
+		}
+		json.NewEncoder(w).Encode(ParallelTaskResult{RunID: id, Status: status})
+	}))
+}
+
+func TestRunTasks(t *testing.T) {
+	var submitted atomic.Int32
+	server := newBatchServer(t, &submitted)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	invalid := ParallelTaskRequest{
+		Input:    "c",
+		TaskSpec: &ParallelTaskSpec{InputSchema: JSONTaskSchema(map[string]any{"type": "object"})},
+	}
+	reqs := []ParallelTaskRequest{{Input: "a"}, {Input: "fail"}, {Input: "b"}, invalid}
+
+	results, err := client.RunTasks(context.Background(), reqs, BatchOptions{
+		Concurrency: 2,
+		Poll:        PollOptions{InitialDelay: time.Millisecond, Interval: time.Millisecond},
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected *BatchError, got %v", err)
+	}
+	if batchErr.Failed != 2 || batchErr.Total != 4 {
+		t.Errorf("Expected 2 of 4 failed, got %d of %d", batchErr.Failed, batchErr.Total)
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Expected errors.Is(err, ErrInvalidRequest) to be true")
+	}
+	if len(results) != len(reqs) {
+		t.Fatalf("Expected %d results, got %d", len(reqs), len(results))
+	}
+	for i, want := range []string{"a", "fail", "b", ""} {
+		if results[i].Index != i || results[i].RunID != want {
+			t.Errorf("Expected result %d to be run %q, got %+v", i, want, results[i])
+		}
+	}
+	if results[0].Err != nil || results[0].Result.Status != TaskStatusCompleted {
+		t.Errorf("Expected first run to complete, got %+v", results[0])
+	}
+	var failed *TaskFailedError
+	if !errors.As(results[1].Err, &failed) || results[1].Result == nil {
+		t.Errorf("Expected second run to report *TaskFailedError with its result, got %+v", results[1])
+	}
+	if submitted.Load() != 3 {
+		t.Errorf("Expected 3 submissions, got %d", submitted.Load())
+	}
+}
+
+func TestRunTasksFailFast(t *testing.T) {
+	var submitted atomic.Int32
+	server := newBatchServer(t, &submitted)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	reqs := []ParallelTaskRequest{{Input: "fail"}, {Input: "a"}, {Input: "b"}, {Input: "c"}}
+
+	results, err := client.RunTasks(context.Background(), reqs, BatchOptions{
+		Concurrency: 1,
+		FailFast:    true,
+		Poll:        PollOptions{InitialDelay: time.Millisecond, Interval: time.Millisecond},
+	})
+	if !errors.Is(err, ErrBatchAborted) {
+		t.Fatalf("Expected errors.Is(err, ErrBatchAborted) to be true, got %v", err)
+	}
+	var failed *TaskFailedError
+	if !errors.As(results[0].Err, &failed) {
+		t.Errorf("Expected first run to report *TaskFailedError, got %+v", results[0])
+	}
+	for _, res := range results[1:] {
+		if !errors.Is(res.Err, ErrBatchAborted) {
+			t.Errorf("Expected item %d to be aborted, got %+v", res.Index, res)
+		}
+	}
+	if submitted.Load() > 2 {
+		t.Errorf("Expected at most 2 submissions, got %d", submitted.Load())
+	}
+}
@@ -0,0 +1,142 @@
+// path: parallel/cache.go
+package parallel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached responses are kept when WithCache is given no TTL.
+const DefaultCacheTTL = time.Hour
+
+// ErrCacheMiss is returned in CacheOnly mode when no cached response exists.
+var ErrCacheMiss = errors.New("parallel: cache miss")
+
+// Cache stores raw response bodies of Search and Extract calls.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// WithCache serves Search and Extract responses from cache and stores fresh
+// ones for ttl, or DefaultCacheTTL if ttl is zero. Responses are keyed by
+// the base URL, operation, a hash of the API key, the headers set by
+// middleware and a canonical hash of the request body, so clients with
+// different credentials or tenant headers sharing one Cache never see each
+// other's responses. Use WithCacheMode to change the behavior of
+// individual calls.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		c.cache, c.cacheTTL = cache, ttl
+	}
+}
+
+// CacheMode controls how a call uses the client's Cache.
+type CacheMode int
+
+const (
+	CacheDefault CacheMode = iota // serve cached responses, store fresh ones
+	CacheBypass                   // neither read nor write the cache
+	CacheRefresh                  // always fetch, then store the fresh response
+	CacheOnly                     // serve cached responses, or fail with ErrCacheMiss without fetching
+)
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context that makes calls use mode.
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+func cacheModeFrom(ctx context.Context) CacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(CacheMode)
+	return mode
+}
+
+// fetchCached wraps next for cacheable calls: it consults and fills the
+// client's Cache according to the context's CacheMode.
+func (c *Client) fetchCached(ctx context.Context, call *Call, next fetchFunc) ([]byte, error) {
+	mode := cacheModeFrom(ctx)
+	if c.cache == nil || mode == CacheBypass {
+		if mode == CacheOnly {
+			return nil, ErrCacheMiss
+		}
+		return next(ctx, call)
+	}
+
+	key, err := c.requestKey(call)
+	if err != nil {
+		return nil, err
+	}
+	if mode != CacheRefresh {
+		if body, ok := c.cache.Get(key); ok {
+			return body, nil
+		}
+	}
+	if mode == CacheOnly {
+		return nil, ErrCacheMiss
+	}
+
+	body, err := next(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	if json.Valid(body) {
+		c.cache.Set(key, body, c.cacheTTL)
+	}
+	return body, nil
+}
+
+// requestKey hashes the base URL, the API key, operation, path, the extra
+// headers set by middleware and the request body in canonical form, so that
+// equivalent requests made with the same credential and headers share a key.
+// It identifies a call for both caching and coalescing. Only a hash of the
+// API key enters the key.
+func (c *Client) requestKey(call *Call) (string, error) {
+	body, err := canonicalJSON(call.Request)
+	if err != nil {
+		return "", &EncodeError{Err: err}
+	}
+	apiKey := sha256.Sum256([]byte(c.apiKey))
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(c.baseURL), apiKey[:], []byte(call.Operation), []byte(call.Method + " " + call.Path), body} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	for _, name := range slices.Sorted(maps.Keys(call.Header)) {
+		for _, v := range call.Header[name] {
+			fmt.Fprintf(h, "%s\x00%s\x00", name, v)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// canonicalJSON encodes v with object keys sorted and insignificant
+// whitespace removed.
+func canonicalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
@@ -0,0 +1,179 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "search-" + string(rune('0'+n))})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(NewMemoryCache(10), time.Minute))
+	ctx := context.Background()
+	search := func(ctx context.Context, req ParallelSearchRequest) string {
+		t.Helper()
+		resp, err := client.Search(ctx, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return resp.SearchID
+	}
+
+	req := ParallelSearchRequest{Objective: "test objective", SearchQueries: []string{"a", "b"}}
+	if id := search(ctx, req); id != "search-1" {
+		t.Errorf("Expected search-1, got %s", id)
+	}
+	if id := search(ctx, req); id != "search-1" {
+		t.Errorf("Expected cached search-1, got %s", id)
+	}
+	if id := search(ctx, ParallelSearchRequest{Objective: "other"}); id != "search-2" {
+		t.Errorf("Expected a different request to miss, got %s", id)
+	}
+	if id := search(WithCacheMode(ctx, CacheBypass), req); id != "search-3" {
+		t.Errorf("Expected bypass to fetch, got %s", id)
+	}
+	if id := search(ctx, req); id != "search-1" {
+		t.Errorf("Expected bypass not to store, got %s", id)
+	}
+	if id := search(WithCacheMode(ctx, CacheRefresh), req); id != "search-4" {
+		t.Errorf("Expected refresh to fetch, got %s", id)
+	}
+	if id := search(WithCacheMode(ctx, CacheOnly), req); id != "search-4" {
+		t.Errorf("Expected refreshed entry, got %s", id)
+	}
+
+	_, err := client.Search(WithCacheMode(ctx, CacheOnly), ParallelSearchRequest{Objective: "uncached"})
+	if !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Expected ErrCacheMiss, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("Expected 4 requests, got %d", calls.Load())
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("Expected least recently used entry to be evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Expected a to be kept, got %q", v)
+	}
+
+	cache.Set("d", []byte("4"), -time.Second)
+	if _, ok := cache.Get("d"); ok {
+		t.Errorf("Expected expired entry to miss")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected only a to remain, got %d entries", cache.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cache.Set("key/with:odd chars", []byte(`{"ok":true}`), time.Minute)
+	cache.Set("expired", []byte("x"), -time.Second)
+
+	reopened, _ := NewDiskCache(dir)
+	if v, ok := reopened.Get("key/with:odd chars"); !ok || string(v) != `{"ok":true}` {
+		t.Errorf("Expected cached value, got %q", v)
+	}
+	if _, ok := reopened.Get("expired"); ok {
+		t.Errorf("Expected expired entry to miss")
+	}
+	if _, ok := reopened.Get("missing"); ok {
+		t.Errorf("Expected missing entry to miss")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected the expired entry to be removed, got %d files", len(entries))
+	}
+
+	// A fresh entry renamed into place after an expired one was read survives.
+	cache.Set("replaced", []byte("old"), -time.Second)
+	stale, err := os.Stat(cache.path("replaced"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cache.Set("replaced", []byte("new"), time.Minute)
+	cache.removeExpired(cache.path("replaced"), stale)
+	if v, ok := cache.Get("replaced"); !ok || string(v) != "new" {
+		t.Errorf("Expected fresh entry to be kept, got %q", v)
+	}
+}
+
+func TestCacheKeyIncludesAPIKey(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: r.Header.Get("x-api-key")})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	req := ParallelSearchRequest{Objective: "test objective"}
+	for _, key := range []string{"key-a", "key-b", "key-a"} {
+		client := NewClient(WithAPIKey(key), WithBaseURL(server.URL), WithCache(cache, time.Minute))
+		resp, err := client.Search(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.SearchID != key {
+			t.Errorf("Expected response for %s, got %s", key, resp.SearchID)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls.Load())
+	}
+}
+
+func TestCacheKeyIncludesHeaders(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: r.Header.Get("x-tenant")})
+	}))
+	defer server.Close()
+
+	type tenantKey struct{}
+	tenant := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.Header.Set("x-tenant", ctx.Value(tenantKey{}).(string))
+			return next(ctx, call)
+		}
+	}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMiddleware(tenant), WithCache(NewMemoryCache(0), 0))
+	req := ParallelSearchRequest{Objective: "test objective"}
+	for _, name := range []string{"a", "b", "a"} {
+		resp, err := client.Search(context.WithValue(context.Background(), tenantKey{}, name), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.SearchID != name {
+			t.Errorf("Expected response for tenant %s, got %s", name, resp.SearchID)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls.Load())
+	}
+}
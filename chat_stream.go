@@ -0,0 +1,138 @@
+// path: parallel/chat_stream.go
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// streamBody is the Call response type for streaming endpoints: send hands
+// over the open response body instead of decoding it.
+type streamBody struct {
+	io.ReadCloser
+}
+
+// ChatStream sends a streaming chat completion request and yields each
+// incremental chunk as it arrives. Iteration stops after the first error.
+// Use a ChatAccumulator to rebuild the final ParallelChatResponse.
+func (c *Client) ChatStream(ctx context.Context, req ParallelChatRequest) iter.Seq2[ParallelChatChunk, error] {
+	return func(yield func(ParallelChatChunk, error) bool) {
+		req.Stream = true
+		body, err := do[streamBody](ctx, c, &Call{
+			Operation:  OpChatStream,
+			Method:     http.MethodPost,
+			Path:       "/chat/completions",
+			Header:     http.Header{"Accept": {"text/event-stream"}},
+			Request:    &req,
+			bearerAuth: true,
+		})
+		if err != nil {
+			yield(ParallelChatChunk{}, err)
+			return
+		}
+		defer body.Close()
+
+		events := newSSEReader(body)
+		for {
+			ev, err := events.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(ParallelChatChunk{}, &TransportError{Err: err})
+				return
+			}
+			if ev.Data == "[DONE]" {
+				return
+			}
+
+			var chunk ParallelChatChunk
+			if err := json.Unmarshal([]byte(ev.Data), &chunk); err != nil {
+				yield(ParallelChatChunk{}, &DecodeError{Err: err})
+				return
+			}
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ChatAccumulator rebuilds a ParallelChatResponse from streamed chunks.
+// The zero value is ready to use.
+type ChatAccumulator struct {
+	resp ParallelChatResponse
+}
+
+// maxChoiceIndexGap bounds how far past the choices seen so far a chunk may
+// index, so a corrupt index cannot make Add allocate without limit.
+const maxChoiceIndexGap = 64
+
+// Add merges chunk into the accumulated response. A chunk with a negative
+// choice index, or one more than maxChoiceIndexGap past the choices seen so
+// far, is rejected as a whole and leaves the response unchanged.
+func (a *ChatAccumulator) Add(chunk ParallelChatChunk) error {
+	for _, delta := range chunk.Choices {
+		if delta.Index < 0 {
+			return &DecodeError{Err: fmt.Errorf("chat chunk %q: negative choice index %d", chunk.ID, delta.Index)}
+		}
+		if delta.Index > len(a.resp.Choices)+maxChoiceIndexGap {
+			return &DecodeError{Err: fmt.Errorf("chat chunk %q: choice index %d out of range", chunk.ID, delta.Index)}
+		}
+	}
+
+	if chunk.ID != "" {
+		a.resp.ID = chunk.ID
+	}
+	if chunk.Model != "" {
+		a.resp.Model = chunk.Model
+	}
+	if chunk.Created != 0 {
+		a.resp.Created = chunk.Created
+	}
+	if chunk.Usage != nil {
+		a.resp.Usage = chunk.Usage
+	}
+
+	for _, delta := range chunk.Choices {
+		for len(a.resp.Choices) <= delta.Index {
+			a.resp.Choices = append(a.resp.Choices, ParallelChatChoice{Index: len(a.resp.Choices)})
+		}
+		choice := &a.resp.Choices[delta.Index]
+		if delta.Delta.Role != "" {
+			choice.Message.Role = delta.Delta.Role
+		}
+		choice.Message.Content += delta.Delta.Content
+		if delta.FinishReason != "" {
+			choice.FinishReason = delta.FinishReason
+		}
+	}
+	return nil
+}
+
+// Response returns the response accumulated so far.
+func (a *ChatAccumulator) Response() *ParallelChatResponse {
+	out := a.resp
+	out.Object = "chat.completion"
+	out.Choices = append([]ParallelChatChoice(nil), a.resp.Choices...)
+	return &out
+}
+
+// collectChatStream consumes a chat stream and returns the accumulated response.
+func (c *Client) collectChatStream(ctx context.Context, req ParallelChatRequest) (*ParallelChatResponse, error) {
+	var acc ChatAccumulator
+	for chunk, err := range c.ChatStream(ctx, req) {
+		if err != nil {
+			return nil, err
+		}
+		if err := acc.Add(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return acc.Response(), nil
+}
@@ -0,0 +1,96 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChatStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/chat/completions" {
+			t.Errorf("Expected to request '/v1beta/chat/completions', got %s", r.URL.Path)
+		}
+		var req ParallelChatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			t.Errorf("Expected stream to be true")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"chat-1\",\"model\":\"speed\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Hel\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"chat-1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo!\"},\"finish_reason\":\"stop\"}]}\r\n\r\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
+	req := ParallelChatRequest{
+		Model:    "speed",
+		Messages: []ParallelChatMessage{{Role: "user", Content: "Hi"}},
+	}
+
+	var deltas []string
+	var acc ChatAccumulator
+	for chunk, err := range client.ChatStream(context.Background(), req) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		deltas = append(deltas, chunk.Choices[0].Delta.Content)
+		if err := acc.Add(chunk); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if strings.Join(deltas, "|") != "Hel|lo!" {
+		t.Errorf("Expected deltas 'Hel|lo!', got %q", strings.Join(deltas, "|"))
+	}
+
+	resp := acc.Response()
+	if resp.ID != "chat-1" || resp.Model != "speed" {
+		t.Errorf("Expected ID 'chat-1' and model 'speed', got %s and %s", resp.ID, resp.Model)
+	}
+	if len(resp.Choices) != 1 {
+		t.Fatalf("Expected 1 choice, got %d", len(resp.Choices))
+	}
+	if resp.Choices[0].Message.Content != "Hello!" || resp.Choices[0].Message.Role != "assistant" {
+		t.Errorf("Expected assistant message 'Hello!', got %+v", resp.Choices[0].Message)
+	}
+	if resp.Choices[0].FinishReason != "stop" {
+		t.Errorf("Expected finish reason 'stop', got %s", resp.Choices[0].FinishReason)
+	}
+
+	req.Stream = true
+	chat, err := client.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error from Chat with Stream set, got %v", err)
+	}
+	if chat.Choices[0].Message.Content != "Hello!" {
+		t.Errorf("Expected Chat to accumulate 'Hello!', got %s", chat.Choices[0].Message.Content)
+	}
+}
+
+func TestChatAccumulatorInvalidIndex(t *testing.T) {
+	for _, index := range []int{-1, 1_000_000_000} {
+		var acc ChatAccumulator
+		acc.Add(ParallelChatChunk{ID: "chat-1", Choices: []ParallelChatChunkChoice{{Delta: ParallelChatDelta{Content: "ok"}}}})
+
+		err := acc.Add(ParallelChatChunk{ID: "chat-1", Choices: []ParallelChatChunkChoice{
+			{Index: 0, Delta: ParallelChatDelta{Content: " more"}},
+			{Index: index, Delta: ParallelChatDelta{Content: "bad"}},
+		}})
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("Expected *DecodeError for index %d, got %v", index, err)
+		}
+		resp := acc.Response()
+		if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != "ok" {
+			t.Errorf("Expected rejected chunk with index %d to leave one choice 'ok', got %+v", index, resp.Choices)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,15 +10,12 @@ import (
 )
 
 func main() {
-	// IMPORTANT: Replace with your actual Parallel API key
-	apiKey := "your-parallel-api-key"
-	if apiKey == "your-parallel-api-key" {
-		fmt.Println("Please replace 'your-parallel-api-key' with your actual Parallel API key.")
+	// 1. Create a new client from PARALLEL_API_KEY (and optional PARALLEL_BASE_URL, ...)
+	client, err := parallel.NewClientFromEnv()
+	if err != nil {
+		fmt.Println("Please set PARALLEL_API_KEY to your Parallel API key:", err)
 		return
 	}
-
-	// 1. Create a new client
-	client := parallel.NewClient(apiKey)
 	ctx := context.Background()
 
 	// 2. Use the Search function
@@ -79,15 +77,18 @@ func main() {
 		fmt.Println("\n--- Testing PollUntilComplete ---")
 		fmt.Println("Polling for task completion (this might take a moment)...")
 		finalResult, err := client.PollUntilComplete(ctx, runID, 5*time.Second)
-		if err != nil {
+		var failed *parallel.TaskFailedError
+		switch {
+		case errors.As(err, &failed):
+			fmt.Printf("Task finished with status: %s\n", failed.Status)
+			if failed.Err != nil {
+				fmt.Println("Task Error:", failed.Err.Message)
+			}
+		case err != nil:
 			fmt.Printf("Error polling for task completion: %v\n", err)
-		} else {
+		default:
 			fmt.Printf("Task finished with status: %s\n", finalResult.Status)
-			if finalResult.Status == "completed" {
-				fmt.Println("Task Output:", finalResult.Output)
-			} else if finalResult.Error != nil {
-				fmt.Println("Task Error:", finalResult.Error)
-			}
+			fmt.Println("Task Output:", finalResult.Output)
 		}
 	}
 
@@ -0,0 +1,84 @@
+// path: parallel/coalesce.go
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// WithRequestCoalescing makes identical idempotent calls that are in flight at
+// the same time share one HTTP exchange. Calls are identical when their
+// operation, path, request body and any headers set by middleware match.
+// Each caller decodes the shared response on its own and keeps its own
+// cancellation: a caller whose context is done returns immediately, and the
+// shared request is cancelled only once every caller waiting on it has left.
+func WithRequestCoalescing() Option {
+	return func(c *Client) {
+		c.flights = &flightGroup{calls: make(map[string]*flight)}
+	}
+}
+
+// flightGroup tracks the shared exchanges in flight, by request key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is one shared exchange.
+type flight struct {
+	done    chan struct{} // closed once body and err are set
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// fetchShared is fetch for idempotent calls when coalescing is enabled.
+func (c *Client) fetchShared(ctx context.Context, call *Call) ([]byte, error) {
+	if _, stream := call.Response.(*streamBody); stream {
+		return c.fetch(ctx, call)
+	}
+	key, err := c.requestKey(call)
+	if err != nil {
+		return nil, err
+	}
+
+	g := c.flights
+	g.mu.Lock()
+	f, ok := g.calls[key]
+	if !ok {
+		// The exchange must outlive the caller that started it, as long as
+		// others are still waiting; it keeps the caller's context values.
+		// It ends once its last waiter has left, not at any one's deadline.
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go func() {
+			f.body, f.err = c.fetch(fctx, call)
+			cancel()
+			g.mu.Lock()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		if f.waiters--; f.waiters == 0 {
+			f.cancel()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
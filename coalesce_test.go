@@ -0,0 +1,191 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	arrived := make(chan struct{}, 10)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		<-release
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "shared"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+	req := ParallelSearchRequest{Objective: "test objective"}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	ids := make([]string, 4)
+	errs := make([]error, 4)
+	for i := range 4 {
+		ctx := context.Background()
+		if i == 0 {
+			ctx = first
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Search(ctx, req)
+			errs[i] = err
+			if err == nil {
+				ids[i] = resp.SearchID
+			}
+		}()
+	}
+	<-arrived
+	for waiting := 0; waiting < 4; time.Sleep(time.Millisecond) {
+		client.flights.mu.Lock()
+		for _, f := range client.flights.calls {
+			waiting = f.waiters
+		}
+		client.flights.mu.Unlock()
+	}
+	cancelFirst()
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 request, got %d", calls.Load())
+	}
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("Expected the cancelled caller to return context.Canceled, got %v", errs[0])
+	}
+	for i := 1; i < 4; i++ {
+		if errs[i] != nil || ids[i] != "shared" {
+			t.Errorf("Expected caller %d to get the shared response, got %q %v", i, ids[i], errs[i])
+		}
+	}
+}
+
+func TestRequestCoalescingAllCancelled(t *testing.T) {
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetTask(ctx, "test-run-id")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("Expected the shared request to be cancelled once every caller left")
+	}
+}
+
+func TestRequestCoalescingJoinerOutlivesDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+	short, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	shortErr := make(chan error)
+	go func() {
+		_, err := client.GetTask(short, "test-run-id")
+		shortErr <- err
+	}()
+	for waiting := 0; waiting < 1; time.Sleep(time.Millisecond) {
+		client.flights.mu.Lock()
+		for _, f := range client.flights.calls {
+			waiting = f.waiters
+		}
+		client.flights.mu.Unlock()
+	}
+
+	type outcome struct {
+		run *ParallelTaskResult
+		err error
+	}
+	long := make(chan outcome)
+	go func() {
+		run, err := client.GetTask(context.Background(), "test-run-id")
+		long <- outcome{run, err}
+	}()
+	for waiting := 0; waiting < 2; time.Sleep(time.Millisecond) {
+		client.flights.mu.Lock()
+		for _, f := range client.flights.calls {
+			waiting = f.waiters
+		}
+		client.flights.mu.Unlock()
+	}
+
+	if err := <-shortErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the short caller to return context.DeadlineExceeded, got %v", err)
+	}
+	close(release)
+	if out := <-long; out.err != nil || out.run.RunID != "test-run-id" {
+		t.Errorf("Expected the joining caller to get the shared response, got %+v %v", out.run, out.err)
+	}
+}
+
+func TestRequestCoalescingHeaders(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: r.Header.Get("x-tenant")})
+	}))
+	defer server.Close()
+
+	type tenantKey struct{}
+	tenant := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.Header.Set("x-tenant", ctx.Value(tenantKey{}).(string))
+			return next(ctx, call)
+		}
+	}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMiddleware(tenant), WithRequestCoalescing())
+	req := ParallelSearchRequest{Objective: "test objective"}
+
+	var wg sync.WaitGroup
+	ids := make([]string, 2)
+	for i, name := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Search(context.WithValue(context.Background(), tenantKey{}, name), req)
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+				return
+			}
+			ids[i] = resp.SearchID
+		}()
+	}
+	for calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("Expected each tenant to get its own response, got %v", ids)
+	}
+}
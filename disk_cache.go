@@ -0,0 +1,100 @@
+// path: parallel/disk_cache.go
+package parallel
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DiskCache is a Cache that keeps one file per entry in a directory, so
+// cached responses survive across processes, e.g. notebook sessions or CI runs.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache storing entries in dir, creating it if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get implements Cache. Expired entries are removed.
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	path := d.path(key)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, false
+	}
+	b, err := io.ReadAll(f)
+	f.Close()
+	if err != nil || len(b) < 8 {
+		return nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+	if time.Now().After(expires) {
+		d.removeExpired(path, info)
+		return nil, false
+	}
+	return b[8:], true
+}
+
+// removeExpired removes the expired entry at path, described by info. A
+// concurrent Set may have renamed a fresh entry into place since it was
+// read, so the file is first moved aside and only deleted if it is the one
+// that was read; otherwise it is linked back unless yet another entry has
+// taken its place.
+func (d *DiskCache) removeExpired(path string, info os.FileInfo) {
+	tmp, err := os.CreateTemp(d.dir, ".expired-*")
+	if err != nil {
+		return
+	}
+	tmp.Close()
+	aside := tmp.Name()
+	if os.Rename(path, aside) != nil {
+		os.Remove(aside)
+		return
+	}
+	if moved, err := os.Stat(aside); err == nil && !os.SameFile(info, moved) {
+		os.Link(aside, path)
+	}
+	os.Remove(aside)
+}
+
+// Set implements Cache. The entry is written to a temporary file and renamed
+// into place, so concurrent readers never see a partial entry. Write errors
+// are ignored; the entry is simply not cached.
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	var header [8]byte
+	binary.BigEndian.PutUint64(header[:], uint64(time.Now().Add(ttl).UnixNano()))
+	_, err = tmp.Write(append(header[:], value...))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		os.Rename(tmp.Name(), d.path(key))
+	}
+}
+
+// path maps key to a file name that is safe on any file system.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
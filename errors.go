@@ -0,0 +1,166 @@
+// path: parallel/errors.go
+package parallel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError via errors.Is.
+var (
+	ErrInvalidRequest = errors.New("parallel: invalid request")
+	ErrUnauthorized   = errors.New("parallel: unauthorized")
+	ErrForbidden      = errors.New("parallel: forbidden")
+	ErrNotFound       = errors.New("parallel: not found")
+	ErrRateLimited    = errors.New("parallel: rate limited")
+	ErrServer         = errors.New("parallel: server error")
+)
+
+// APIError is returned when the Parallel API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int    // HTTP status code, e.g. 429
+	Status     string // HTTP status line, e.g. "429 Too Many Requests"
+	Code       string // machine-readable error code from the body, if any
+	Message    string // human-readable message from the body, if any
+	RequestID  string // request ID from the response headers or body, if any
+	Body       []byte // raw response body
+}
+
+func (e *APIError) Error() string {
+	detail := strings.TrimSpace(string(e.Body))
+	if e.Message != "" {
+		detail = e.Message
+		if e.Code != "" {
+			detail = e.Code + ": " + detail
+		}
+	}
+	return fmt.Sprintf("API error: %s — %s", e.Status, detail)
+}
+
+// Is reports whether the error's status code matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response, parsing the JSON
+// error body when there is one.
+func newAPIError(res *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		RequestID:  res.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+
+	var envelope struct {
+		Error   json.RawMessage `json:"error"`
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		return e
+	}
+	e.Code, e.Message = envelope.Code, envelope.Message
+
+	var nested struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		RefID   string `json:"ref_id"`
+	}
+	var flat string
+	switch {
+	case json.Unmarshal(envelope.Error, &nested) == nil:
+		if nested.Code != "" {
+			e.Code = nested.Code
+		}
+		if nested.Message != "" {
+			e.Message = nested.Message
+		}
+		if e.RequestID == "" {
+			e.RequestID = nested.RefID
+		}
+	case json.Unmarshal(envelope.Error, &flat) == nil && flat != "":
+		e.Message = flat
+	}
+	return e
+}
+
+// ValidationError is returned when a request fails local validation before
+// being sent. It matches ErrInvalidRequest.
+type ValidationError struct {
+	Field   string // offending field, e.g. "input.address.city"
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid request: %s: %s", e.Field, e.Message)
+}
+
+// Is reports whether target is ErrInvalidRequest.
+func (e *ValidationError) Is(target error) bool { return target == ErrInvalidRequest }
+
+// joinField appends a nested path to a field name.
+func joinField(field, path string) string {
+	switch {
+	case path == "":
+		return field
+	case strings.HasPrefix(path, "["):
+		return field + path
+	}
+	return field + "." + path
+}
+
+// Error implements error, so that per-URL failures can be returned as errors.
+func (e *ParallelAPIError) Error() string {
+	switch {
+	case e.URL != "" && e.ErrorType != "":
+		return fmt.Sprintf("extract %s: %s: %s", e.URL, e.ErrorType, e.Message)
+	case e.URL != "":
+		return fmt.Sprintf("extract %s: %s", e.URL, e.Message)
+	}
+	return e.Message
+}
+
+// TransportError is returned when the HTTP request could not be completed.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string { return fmt.Sprintf("send request: %v", e.Err) }
+
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// EncodeError is returned when the request could not be encoded.
+type EncodeError struct {
+	Err error
+}
+
+func (e *EncodeError) Error() string { return fmt.Sprintf("marshal request: %v", e.Err) }
+
+func (e *EncodeError) Unwrap() error { return e.Err }
+
+// DecodeError is returned when a successful response could not be decoded.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string { return fmt.Sprintf("decode response: %v", e.Err) }
+
+func (e *DecodeError) Unwrap() error { return e.Err }
@@ -0,0 +1,73 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIErrorJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"type":"error","error":{"code":"invalid_input","message":"objective is required"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	_, err := client.Extract(context.Background(), ParallelExtractRequest{URLs: []string{"https://example.com"}})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T (%v)", err, err)
+	}
+	if apiErr.Code != "invalid_input" {
+		t.Errorf("Expected Code to be 'invalid_input', got %s", apiErr.Code)
+	}
+	if apiErr.Message != "objective is required" {
+		t.Errorf("Expected Message to be 'objective is required', got %s", apiErr.Message)
+	}
+	if apiErr.RequestID != "req-42" {
+		t.Errorf("Expected RequestID to be 'req-42', got %s", apiErr.RequestID)
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Expected errors.Is(err, ErrInvalidRequest) to be true")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrNotFound) to be false")
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := client.GetTask(context.Background(), "test-run-id")
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("Expected *TransportError, got %T (%v)", err, err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not json")
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	_, err := client.GetTask(context.Background(), "test-run-id")
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected *DecodeError, got %T (%v)", err, err)
+	}
+}
@@ -0,0 +1,121 @@
+// path: parallel/extract_all.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Defaults used by ExtractAll when the corresponding ExtractAllOptions field is zero.
+const (
+	DefaultExtractChunkSize   = 10
+	DefaultExtractConcurrency = 4
+)
+
+// ExtractErrorRequestFailed is the ParallelAPIError.ErrorType reported by
+// ExtractAll for each URL of a chunk whose request failed as a whole.
+const ExtractErrorRequestFailed = "request_failed"
+
+// ExtractAllOptions configures ExtractAll.
+type ExtractAllOptions struct {
+	ChunkSize   int // maximum number of URLs per request
+	Concurrency int // maximum number of requests in flight
+}
+
+// ExtractAll extracts any number of URLs by splitting req.URLs into chunks,
+// extracting them concurrently and merging the responses in input order.
+//
+// A chunk whose request fails is reported as one ParallelAPIError per URL,
+// so every failure stays associated with its URL; see FailedURLs. The merged
+// ExtractID lists the chunk extract IDs separated by commas. An error is
+// returned if req is invalid, if ctx is done before every chunk finished, or
+// if every chunk failed; in the last two cases together with the merged response.
+func (c *Client) ExtractAll(ctx context.Context, req ParallelExtractRequest, opts ExtractAllOptions) (*ParallelExtractResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = DefaultExtractChunkSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultExtractConcurrency
+	}
+
+	chunks := slices.Collect(slices.Chunk(req.URLs, size))
+	responses := make([]*ParallelExtractResponse, len(chunks))
+	errs := make([]error, len(chunks))
+
+	started := runBounded(ctx, len(chunks), concurrency, func(i int) {
+		chunk := req
+		chunk.URLs = chunks[i]
+		responses[i], errs[i] = c.Extract(ctx, chunk)
+	})
+	for i := started; i < len(chunks); i++ {
+		errs[i] = ctx.Err()
+	}
+
+	merged := &ParallelExtractResponse{}
+	var ids []string
+	var firstErr error
+	failed := 0
+	for i, urls := range chunks {
+		if err := errs[i]; err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			merged.Errors = append(merged.Errors, chunkErrors(urls, err)...)
+			continue
+		}
+		resp := responses[i]
+		if resp.ExtractID != "" {
+			ids = append(ids, resp.ExtractID)
+		}
+		merged.Results = append(merged.Results, resp.Results...)
+		merged.Errors = append(merged.Errors, resp.Errors...)
+	}
+	merged.ExtractID = strings.Join(ids, ",")
+
+	if err := ctx.Err(); err != nil {
+		return merged, err
+	}
+	if failed > 0 && failed == len(chunks) {
+		return merged, firstErr
+	}
+	return merged, nil
+}
+
+// chunkErrors reports err once for each URL of a failed chunk.
+func chunkErrors(urls []string, err error) []ParallelAPIError {
+	status := 0
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+	out := make([]ParallelAPIError, len(urls))
+	for i, u := range urls {
+		out[i] = ParallelAPIError{
+			URL:            u,
+			ErrorType:      ExtractErrorRequestFailed,
+			HTTPStatusCode: status,
+			Message:        err.Error(),
+		}
+	}
+	return out
+}
+
+// FailedURLs returns the URLs of all per-URL errors in the response, in
+// order, e.g. to retry just those.
+func (r *ParallelExtractResponse) FailedURLs() []string {
+	var urls []string
+	for _, e := range r.Errors {
+		if e.URL != "" {
+			urls = append(urls, e.URL)
+		}
+	}
+	return urls
+}
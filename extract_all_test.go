@@ -0,0 +1,125 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExtractAll(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.URLs) > 2 {
+			t.Errorf("Expected at most 2 URLs per request, got %d", len(req.URLs))
+		}
+		if req.Objective != "pricing" {
+			t.Errorf("Expected objective to be forwarded, got %q", req.Objective)
+		}
+		if slices.Contains(req.URLs, "https://c.example") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"bad chunk"}}`))
+			return
+		}
+
+		resp := ParallelExtractResponse{ExtractID: "extract_" + strings.TrimPrefix(req.URLs[0], "https://")}
+		for _, u := range req.URLs {
+			if u == "https://e.example" {
+				resp.Errors = append(resp.Errors, ParallelAPIError{URL: u, ErrorType: "fetch_error", HTTPStatusCode: 404, Message: "not found"})
+				continue
+			}
+			resp.Results = append(resp.Results, ParallelExtract{URL: u})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	urls := []string{"https://a.example", "https://b.example", "https://c.example", "https://d.example", "https://e.example"}
+
+	resp, err := client.ExtractAll(context.Background(), ParallelExtractRequest{URLs: urls, Objective: "pricing"}, ExtractAllOptions{ChunkSize: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var got []string
+	for _, res := range resp.Results {
+		got = append(got, res.URL)
+	}
+	if want := []string{"https://a.example", "https://b.example"}; !slices.Equal(got, want) {
+		t.Errorf("Expected results %v, got %v", want, got)
+	}
+	if want := []string{"https://c.example", "https://d.example", "https://e.example"}; !slices.Equal(resp.FailedURLs(), want) {
+		t.Errorf("Expected failed URLs %v, got %v", want, resp.FailedURLs())
+	}
+	if e := resp.Errors[0]; e.ErrorType != ExtractErrorRequestFailed || e.HTTPStatusCode != http.StatusBadRequest {
+		t.Errorf("Expected chunk failure to be reported per URL, got %+v", e)
+	}
+	if resp.ExtractID != "extract_a.example,extract_e.example" {
+		t.Errorf("Expected merged ExtractID, got %q", resp.ExtractID)
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight.Load())
+	}
+}
+
+func TestExtractAllFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	urls := []string{"https://a.example", "https://b.example", "https://c.example"}
+
+	resp, err := client.ExtractAll(context.Background(), ParallelExtractRequest{URLs: urls}, ExtractAllOptions{ChunkSize: 2})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected errors.Is(err, ErrUnauthorized) to be true, got %v", err)
+	}
+	if !slices.Equal(resp.FailedURLs(), urls) {
+		t.Errorf("Expected all URLs to fail, got %v", resp.FailedURLs())
+	}
+}
+
+func TestExtractAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.URLs[0] == "https://a.example" {
+			json.NewEncoder(w).Encode(ParallelExtractResponse{Results: []ParallelExtract{{URL: req.URLs[0]}}})
+			return
+		}
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	urls := []string{"https://a.example", "https://b.example", "https://c.example"}
+
+	resp, err := client.ExtractAll(ctx, ParallelExtractRequest{URLs: urls}, ExtractAllOptions{ChunkSize: 1, Concurrency: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(resp.Results) != 1 || !slices.Equal(resp.FailedURLs(), urls[1:]) {
+		t.Errorf("Expected the finished chunk and failed URLs in the response, got %+v", resp)
+	}
+}
@@ -0,0 +1,284 @@
+// path: parallel/extract_batcher.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults used by an ExtractBatcher when ExtractBatcherOptions leaves a field zero.
+const (
+	DefaultExtractBatchWindow  = 50 * time.Millisecond
+	DefaultExtractBatchTimeout = 2 * time.Minute
+)
+
+// ExtractErrorMissingResult is the ParallelAPIError.ErrorType reported by an
+// ExtractBatcher for a URL the API returned neither a result nor an error for.
+const ExtractErrorMissingResult = "missing_result"
+
+// ErrBatcherClosed is returned by ExtractBatcher.Extract after Close, and to
+// callers whose batch was still open or in flight when Close was called.
+var ErrBatcherClosed = errors.New("parallel: extract batcher closed")
+
+// ExtractBatcherOptions configures an ExtractBatcher.
+type ExtractBatcherOptions struct {
+	Window      time.Duration // how long a batch collects URLs after its first one
+	MaxURLs     int           // send a batch as soon as it holds this many URLs; defaults to DefaultExtractChunkSize
+	Concurrency int           // maximum number of batch requests in flight; defaults to DefaultExtractConcurrency
+	Timeout     time.Duration // bound on each batch request; defaults to DefaultExtractBatchTimeout
+
+	// Partition, if set, keeps callers whose contexts map to different
+	// values in separate batches, e.g. one per tenant when middleware sets
+	// headers or credentials from context values.
+	Partition func(ctx context.Context) string
+}
+
+// ExtractBatcher combines single-URL Extract calls made around the same time,
+// from any number of goroutines, into multi-URL requests. Requests are
+// batched together only if they are identical apart from their URL, are
+// made with the same CacheMode and fall in the same Partition.
+//
+// A batch request is made with the context values of the caller that opened
+// the batch, but not its cancellation, since other callers may be waiting on
+// it; it is bounded by ExtractBatcherOptions.Timeout instead. Middleware that
+// derives headers from context values therefore sees only the first caller's
+// values: set ExtractBatcherOptions.Partition so that callers who must not
+// share them are batched apart.
+type ExtractBatcher struct {
+	client *Client
+	opts   ExtractBatcherOptions
+	ctx    context.Context // cancelled by Close
+	cancel context.CancelFunc
+	slots  chan struct{} // one per batch request in flight
+
+	mu      sync.Mutex
+	pending map[extractBatchKey]*extractBatch // open batches
+	closed  bool
+	sending sync.WaitGroup
+}
+
+// extractBatchKey identifies the requests that may share a batch.
+type extractBatchKey struct {
+	mode      CacheMode
+	partition string
+	req       string // canonical request without its URLs
+}
+
+// extractBatch is a request being collected, with the callers waiting on it.
+type extractBatch struct {
+	ctx     context.Context // the opening caller's context, without its cancellation
+	req     ParallelExtractRequest
+	waiters map[string][]chan extractOutcome // by URL
+	urls    []string                         // distinct URLs in arrival order
+	timer   *time.Timer
+}
+
+type extractOutcome struct {
+	result *ParallelExtract
+	err    error
+}
+
+// NewExtractBatcher returns an ExtractBatcher sending through c. Call Flush
+// to send open batches early, and Close when done.
+func (c *Client) NewExtractBatcher(opts ExtractBatcherOptions) *ExtractBatcher {
+	if opts.Window <= 0 {
+		opts.Window = DefaultExtractBatchWindow
+	}
+	if opts.MaxURLs <= 0 {
+		opts.MaxURLs = DefaultExtractChunkSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultExtractConcurrency
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultExtractBatchTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ExtractBatcher{
+		client:  c,
+		opts:    opts,
+		ctx:     ctx,
+		cancel:  cancel,
+		slots:   make(chan struct{}, opts.Concurrency),
+		pending: make(map[extractBatchKey]*extractBatch),
+	}
+}
+
+// Extract extracts the single URL in req as part of a batch and returns its
+// result. A per-URL failure reported by the API is returned as a
+// *ParallelAPIError; if the whole batch request fails, its error is returned.
+//
+// If ctx is done first, Extract returns early; the batch is still sent for
+// the other callers.
+func (b *ExtractBatcher) Extract(ctx context.Context, req ParallelExtractRequest) (*ParallelExtract, error) {
+	if len(req.URLs) != 1 {
+		return nil, &ValidationError{Field: "urls", Message: "must hold exactly one URL"}
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	url := req.URLs[0]
+	req.URLs = nil
+	body, err := canonicalJSON(req)
+	if err != nil {
+		return nil, &EncodeError{Err: err}
+	}
+	key := extractBatchKey{mode: cacheModeFrom(ctx), req: string(body)}
+	if b.opts.Partition != nil {
+		key.partition = b.opts.Partition(ctx)
+	}
+
+	done := make(chan extractOutcome, 1)
+	if err := b.add(ctx, key, req, url, done); err != nil {
+		return nil, err
+	}
+
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// add registers a waiter for url in the open batch for key, opening one if
+// needed, and sends the batch once it is full.
+func (b *ExtractBatcher) add(ctx context.Context, key extractBatchKey, req ParallelExtractRequest, url string, done chan extractOutcome) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrBatcherClosed
+	}
+
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &extractBatch{ctx: context.WithoutCancel(ctx), req: req, waiters: make(map[string][]chan extractOutcome)}
+		batch.timer = time.AfterFunc(b.opts.Window, func() { b.flushKey(key, batch) })
+		b.pending[key] = batch
+	}
+	if _, seen := batch.waiters[url]; !seen {
+		batch.urls = append(batch.urls, url)
+	}
+	batch.waiters[url] = append(batch.waiters[url], done)
+
+	if len(batch.urls) >= b.opts.MaxURLs {
+		b.sendLocked(key, batch)
+	}
+	return nil
+}
+
+// flushKey sends batch if it is still the open batch for key.
+func (b *ExtractBatcher) flushKey(key extractBatchKey, batch *extractBatch) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending[key] == batch {
+		b.sendLocked(key, batch)
+	}
+}
+
+// Flush sends all open batches without waiting for their window to end.
+func (b *ExtractBatcher) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, batch := range b.pending {
+		b.sendLocked(key, batch)
+	}
+}
+
+// Close stops the batcher: open batches are dropped and batch requests in
+// flight are cancelled, their callers failing with ErrBatcherClosed. Close
+// returns once every batch request has ended. Call Flush first and wait for
+// the pending Extract calls to send what was collected.
+func (b *ExtractBatcher) Close() {
+	b.mu.Lock()
+	b.closed = true
+	for key, batch := range b.pending {
+		delete(b.pending, key)
+		batch.timer.Stop()
+		batch.deliver(nil, ErrBatcherClosed)
+	}
+	b.mu.Unlock()
+	b.cancel()
+	b.sending.Wait()
+}
+
+// sendLocked closes batch to new URLs and sends it in the background, once
+// a request slot is free. b.mu must be held.
+func (b *ExtractBatcher) sendLocked(key extractBatchKey, batch *extractBatch) {
+	delete(b.pending, key)
+	batch.timer.Stop()
+	b.sending.Add(1)
+	go func() {
+		defer b.sending.Done()
+		select {
+		case b.slots <- struct{}{}:
+			defer func() { <-b.slots }()
+		case <-b.ctx.Done():
+			batch.deliver(nil, ErrBatcherClosed)
+			return
+		}
+		batch.send(b.ctx, b.client, b.opts.Timeout)
+	}()
+}
+
+// send performs the batch request, cancelling it if closing is done first.
+func (batch *extractBatch) send(closing context.Context, c *Client, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(batch.ctx, timeout)
+	defer cancel()
+	stop := context.AfterFunc(closing, cancel)
+	defer stop()
+
+	req := batch.req
+	req.URLs = batch.urls
+	resp, err := c.Extract(ctx, req)
+	if err != nil && closing.Err() != nil {
+		err = ErrBatcherClosed
+	}
+	batch.deliver(resp, err)
+}
+
+// deliver hands each waiter the outcome for its URL.
+func (batch *extractBatch) deliver(resp *ParallelExtractResponse, err error) {
+	results := make(map[string]*ParallelExtract)
+	failures := make(map[string]*ParallelAPIError)
+	if err == nil {
+		for i := range resp.Results {
+			r := &resp.Results[i]
+			results[r.URL] = r
+			results[CanonicalURL(r.URL)] = r
+		}
+		for i := range resp.Errors {
+			e := &resp.Errors[i]
+			failures[e.URL] = e
+			failures[CanonicalURL(e.URL)] = e
+		}
+	}
+
+	for url, waiters := range batch.waiters {
+		var out extractOutcome
+		switch {
+		case err != nil:
+			out.err = err
+		case results[url] != nil:
+			out.result = results[url]
+		case failures[url] != nil:
+			out.err = failures[url]
+		case results[CanonicalURL(url)] != nil:
+			out.result = results[CanonicalURL(url)]
+		case failures[CanonicalURL(url)] != nil:
+			out.err = failures[CanonicalURL(url)]
+		default:
+			out.err = &ParallelAPIError{URL: url, ErrorType: ExtractErrorMissingResult, Message: "no result returned for URL"}
+		}
+		for _, done := range waiters {
+			if out.result != nil {
+				result := *out.result
+				done <- extractOutcome{result: &result}
+				continue
+			}
+			done <- out
+		}
+	}
+}
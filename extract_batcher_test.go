@@ -0,0 +1,256 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExtractBatcher(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		batches = append(batches, req.URLs)
+		mu.Unlock()
+
+		var resp ParallelExtractResponse
+		for _, u := range req.URLs {
+			if u == "https://broken.example" {
+				resp.Errors = append(resp.Errors, ParallelAPIError{URL: u, ErrorType: "fetch_error", Message: "timeout"})
+				continue
+			}
+			resp.Results = append(resp.Results, ParallelExtract{URL: u, Title: req.Objective})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	batcher := client.NewExtractBatcher(ExtractBatcherOptions{Window: 20 * time.Millisecond})
+	defer batcher.Close()
+
+	requests := []ParallelExtractRequest{
+		{URLs: []string{"https://a.example"}, Objective: "one"},
+		{URLs: []string{"https://b.example"}, Objective: "one"},
+		{URLs: []string{"https://a.example"}, Objective: "one"},
+		{URLs: []string{"https://broken.example"}, Objective: "one"},
+		{URLs: []string{"https://c.example"}, Objective: "two"},
+	}
+	results := make([]*ParallelExtract, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = batcher.Extract(context.Background(), req)
+		}()
+	}
+	wg.Wait()
+
+	for _, i := range []int{0, 1, 2, 4} {
+		want := requests[i]
+		if errs[i] != nil || results[i].URL != want.URLs[0] || results[i].Title != want.Objective {
+			t.Errorf("Expected result for %s (%s), got %+v %v", want.URLs[0], want.Objective, results[i], errs[i])
+		}
+	}
+	var apiErr *ParallelAPIError
+	if !errors.As(errs[3], &apiErr) || apiErr.ErrorType != "fetch_error" {
+		t.Errorf("Expected *ParallelAPIError for the broken URL, got %v", errs[3])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 {
+		t.Fatalf("Expected 2 requests, got %d: %v", len(batches), batches)
+	}
+	for _, urls := range batches {
+		if len(urls) != 1 && len(urls) != 3 {
+			t.Errorf("Expected batches grouped by objective with duplicate URLs merged, got %v", urls)
+		}
+	}
+}
+
+func TestExtractBatcherSizeAndClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var resp ParallelExtractResponse
+		if len(req.URLs) == 2 {
+			resp.Results = []ParallelExtract{{URL: req.URLs[0]}, {URL: req.URLs[1]}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	batcher := client.NewExtractBatcher(ExtractBatcherOptions{Window: time.Hour, MaxURLs: 2})
+
+	var wg sync.WaitGroup
+	for _, u := range []string{"https://a.example", "https://b.example"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res, err := batcher.Extract(context.Background(), ParallelExtractRequest{URLs: []string{u}}); err != nil || res.URL != u {
+				t.Errorf("Expected a full batch to be sent before its window ends, got %+v %v", res, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	done := make(chan error)
+	go func() {
+		_, err := batcher.Extract(context.Background(), ParallelExtractRequest{URLs: []string{"https://c.example"}})
+		done <- err
+	}()
+	for {
+		batcher.mu.Lock()
+		n := len(batcher.pending)
+		batcher.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	batcher.Close()
+
+	if err := <-done; !errors.Is(err, ErrBatcherClosed) {
+		t.Errorf("Expected an open batch to fail with ErrBatcherClosed, got %v", err)
+	}
+	if _, err := batcher.Extract(context.Background(), ParallelExtractRequest{URLs: []string{"https://c.example"}}); !errors.Is(err, ErrBatcherClosed) {
+		t.Errorf("Expected ErrBatcherClosed, got %v", err)
+	}
+}
+
+func TestExtractBatcherCloseCancelsSends(t *testing.T) {
+	arrived := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		close(arrived)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	batcher := client.NewExtractBatcher(ExtractBatcherOptions{Window: time.Millisecond})
+
+	done := make(chan error)
+	go func() {
+		_, err := batcher.Extract(context.Background(), ParallelExtractRequest{URLs: []string{"https://a.example"}})
+		done <- err
+	}()
+	<-arrived
+	batcher.Close()
+
+	if err := <-done; !errors.Is(err, ErrBatcherClosed) {
+		t.Errorf("Expected ErrBatcherClosed, got %v", err)
+	}
+}
+
+func TestExtractBatcherCacheModes(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var resp ParallelExtractResponse
+		for _, u := range req.URLs {
+			resp.Results = append(resp.Results, ParallelExtract{URL: u})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(NewMemoryCache(10), time.Minute))
+	batcher := client.NewExtractBatcher(ExtractBatcherOptions{Window: time.Hour})
+	defer batcher.Close()
+
+	var wg sync.WaitGroup
+	for i, mode := range []CacheMode{CacheDefault, CacheBypass} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u := []string{"https://a.example", "https://b.example"}[i]
+			if _, err := batcher.Extract(WithCacheMode(context.Background(), mode), ParallelExtractRequest{URLs: []string{u}}); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	for {
+		batcher.mu.Lock()
+		n := len(batcher.pending)
+		batcher.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	batcher.Flush()
+	wg.Wait()
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected calls with different cache modes to be sent apart, got %d requests", calls.Load())
+	}
+	if _, err := client.Extract(WithCacheMode(context.Background(), CacheOnly), ParallelExtractRequest{URLs: []string{"https://b.example"}}); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Expected the bypassed batch not to be cached, got %v", err)
+	}
+}
+
+func TestExtractBatcherPartition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelExtractRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var resp ParallelExtractResponse
+		for _, u := range req.URLs {
+			resp.Results = append(resp.Results, ParallelExtract{URL: u, Title: r.Header.Get("x-tenant")})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	type tenantKey struct{}
+	tenantFrom := func(ctx context.Context) string { return ctx.Value(tenantKey{}).(string) }
+	tenant := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.Header.Set("x-tenant", tenantFrom(ctx))
+			return next(ctx, call)
+		}
+	}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMiddleware(tenant))
+	batcher := client.NewExtractBatcher(ExtractBatcherOptions{Window: time.Hour, Partition: tenantFrom})
+	defer batcher.Close()
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), tenantKey{}, name)
+			res, err := batcher.Extract(ctx, ParallelExtractRequest{URLs: []string{"https://" + name + ".example"}})
+			if err != nil || res.Title != name {
+				t.Errorf("Expected tenant %s to be extracted under its own header, got %+v %v", name, res, err)
+			}
+		}()
+	}
+	for {
+		batcher.mu.Lock()
+		n := len(batcher.pending)
+		batcher.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	batcher.Flush()
+	wg.Wait()
+}
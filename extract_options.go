@@ -0,0 +1,92 @@
+// path: parallel/extract_options.go
+package parallel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// MarshalJSON sends "excerpts" and "full_content" as settings objects when
+// ExcerptSettings or FullContentSettings are set, and as booleans otherwise.
+func (r ParallelExtractRequest) MarshalJSON() ([]byte, error) {
+	type plain ParallelExtractRequest
+	out := struct {
+		plain
+		Excerpts    any `json:"excerpts"`
+		FullContent any `json:"full_content"`
+	}{plain: plain(r), Excerpts: r.Excerpts, FullContent: r.FullContent}
+	if r.ExcerptSettings != nil {
+		out.Excerpts = r.ExcerptSettings
+	}
+	if r.FullContentSettings != nil {
+		out.FullContent = r.FullContentSettings
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON accepts "excerpts" and "full_content" as booleans or settings objects.
+func (r *ParallelExtractRequest) UnmarshalJSON(b []byte) error {
+	type plain ParallelExtractRequest
+	in := struct {
+		*plain
+		Excerpts    json.RawMessage `json:"excerpts"`
+		FullContent json.RawMessage `json:"full_content"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	var err error
+	if r.Excerpts, r.ExcerptSettings, err = decodeContentSetting[ExcerptSettings](in.Excerpts); err != nil {
+		return err
+	}
+	r.FullContent, r.FullContentSettings, err = decodeContentSetting[FullContentSettings](in.FullContent)
+	return err
+}
+
+// decodeContentSetting decodes a field that is either a boolean or a settings object.
+func decodeContentSetting[T any](raw json.RawMessage) (bool, *T, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return false, nil, nil
+	}
+	var enabled bool
+	if json.Unmarshal(raw, &enabled) == nil {
+		return enabled, nil, nil
+	}
+	settings := new(T)
+	if err := json.Unmarshal(raw, settings); err != nil {
+		return false, nil, err
+	}
+	return true, settings, nil
+}
+
+// Validate checks the request locally: it needs at least one absolute http(s)
+// URL, and character limits and fetch policy durations must not be negative.
+// Extract calls it before sending.
+func (r ParallelExtractRequest) Validate() error {
+	if len(r.URLs) == 0 {
+		return &ValidationError{Field: "urls", Message: "at least one URL is required"}
+	}
+	for i, raw := range r.URLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &ValidationError{Field: fmt.Sprintf("urls[%d]", i), Message: fmt.Sprintf("%q is not an absolute http(s) URL", raw)}
+		}
+	}
+	switch {
+	case r.ExcerptSettings != nil && r.ExcerptSettings.MaxCharsPerResult < 0:
+		return &ValidationError{Field: "excerpts.max_chars_per_result", Message: "must not be negative"}
+	case r.FullContentSettings != nil && r.FullContentSettings.MaxCharsPerResult < 0:
+		return &ValidationError{Field: "full_content.max_chars_per_result", Message: "must not be negative"}
+	}
+	if p := r.FetchPolicy; p != nil {
+		switch {
+		case p.MaxAgeSeconds < 0:
+			return &ValidationError{Field: "fetch_policy.max_age_seconds", Message: "must not be negative"}
+		case p.TimeoutSeconds < 0:
+			return &ValidationError{Field: "fetch_policy.timeout_seconds", Message: "must not be negative"}
+		}
+	}
+	return nil
+}
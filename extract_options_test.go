@@ -0,0 +1,81 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtractSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if excerpts, ok := body["excerpts"].(map[string]any); !ok || excerpts["max_chars_per_result"] != 500.0 {
+			t.Errorf("Expected excerpts settings object, got %v", body["excerpts"])
+		}
+		if body["full_content"] != false {
+			t.Errorf("Expected full_content to be false, got %v", body["full_content"])
+		}
+		policy, _ := body["fetch_policy"].(map[string]any)
+		if policy["max_age_seconds"] != 3600.0 || policy["disable_cache_fallback"] != true {
+			t.Errorf("Expected fetch policy to be sent, got %v", body["fetch_policy"])
+		}
+		json.NewEncoder(w).Encode(ParallelExtractResponse{ExtractID: "test-extract-id"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	_, err := client.Extract(context.Background(), ParallelExtractRequest{
+		URLs:            []string{"https://example.com/news"},
+		ExcerptSettings: &ExcerptSettings{MaxCharsPerResult: 500},
+		FetchPolicy:     &FetchPolicy{MaxAgeSeconds: 3600, DisableCacheFallback: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestExtractRequestJSON(t *testing.T) {
+	var req ParallelExtractRequest
+	body := `{"urls":["https://example.com"],"excerpts":true,"full_content":{"max_chars_per_result":1000}}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !req.Excerpts || req.ExcerptSettings != nil {
+		t.Errorf("Expected excerpts flag without settings, got %v %+v", req.Excerpts, req.ExcerptSettings)
+	}
+	if !req.FullContent || req.FullContentSettings == nil || req.FullContentSettings.MaxCharsPerResult != 1000 {
+		t.Errorf("Expected full content settings, got %v %+v", req.FullContent, req.FullContentSettings)
+	}
+
+	out, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := `{"urls":["https://example.com"],"objective":"","excerpts":true,"full_content":{"max_chars_per_result":1000}}`
+	if string(out) != want {
+		t.Errorf("Expected %s, got %s", want, out)
+	}
+}
+
+func TestExtractRequestValidate(t *testing.T) {
+	tests := map[string]struct {
+		req   ParallelExtractRequest
+		field string
+	}{
+		"no urls":       {ParallelExtractRequest{}, "urls"},
+		"relative url":  {ParallelExtractRequest{URLs: []string{"https://example.com", "/about"}}, "urls[1]"},
+		"ftp url":       {ParallelExtractRequest{URLs: []string{"ftp://example.com/file"}}, "urls[0]"},
+		"excerpt chars": {ParallelExtractRequest{URLs: []string{"https://example.com"}, ExcerptSettings: &ExcerptSettings{MaxCharsPerResult: -1}}, "excerpts.max_chars_per_result"},
+		"max age":       {ParallelExtractRequest{URLs: []string{"https://example.com"}, FetchPolicy: &FetchPolicy{MaxAgeSeconds: -5}}, "fetch_policy.max_age_seconds"},
+	}
+	for name, tt := range tests {
+		var verr *ValidationError
+		if err := tt.req.Validate(); !errors.As(err, &verr) || verr.Field != tt.field {
+			t.Errorf("%s: Expected *ValidationError for %q, got %v", name, tt.field, err)
+		}
+	}
+}
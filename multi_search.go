@@ -0,0 +1,173 @@
+// path: parallel/multi_search.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Defaults used by MultiSearch when the corresponding MultiSearchOptions field is zero.
+const (
+	DefaultSearchConcurrency = 4
+	DefaultRRFConstant       = 60
+)
+
+// MultiSearchOptions configures MultiSearch.
+type MultiSearchOptions struct {
+	Concurrency int // maximum number of searches in flight
+	RRFConstant int // k in the reciprocal rank fusion score 1/(k+rank)
+	MaxResults  int // maximum number of merged results; zero keeps all
+}
+
+// MultiSearchResponse is the merged outcome of a MultiSearch.
+type MultiSearchResponse struct {
+	Results  []MultiSearchResult       // deduplicated results, best first
+	Searches []*ParallelSearchResponse // response per request; nil if it failed
+	Errors   []error                   // error per request; nil if it succeeded
+}
+
+// MultiSearchResult is one deduplicated search result.
+type MultiSearchResult struct {
+	ParallelResult                // URL as first seen; Excerpts merged across duplicates
+	CanonicalURL   string         // key used for deduplication; see CanonicalURL
+	Score          float64        // reciprocal rank fusion score
+	Sources        []SearchSource // the searches that returned this result
+}
+
+// SearchSource records where a MultiSearch result was found.
+type SearchSource struct {
+	Request int // index of the request passed to MultiSearch
+	Rank    int // 1-based position in that request's results
+}
+
+// MultiSearch runs reqs concurrently and merges their results into one list.
+// Results are deduplicated by CanonicalURL, their excerpts merged, and ranked
+// by reciprocal rank fusion: each result scores the sum of 1/(k+rank) over
+// the searches that returned it.
+//
+// Failed searches are recorded in Errors; an error is returned only if
+// every search failed.
+func (c *Client) MultiSearch(ctx context.Context, reqs []ParallelSearchRequest, opts MultiSearchOptions) (*MultiSearchResponse, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSearchConcurrency
+	}
+	k := opts.RRFConstant
+	if k <= 0 {
+		k = DefaultRRFConstant
+	}
+
+	out := &MultiSearchResponse{
+		Searches: make([]*ParallelSearchResponse, len(reqs)),
+		Errors:   make([]error, len(reqs)),
+	}
+	started := runBounded(ctx, len(reqs), concurrency, func(i int) {
+		out.Searches[i], out.Errors[i] = c.Search(ctx, reqs[i])
+	})
+	for i := started; i < len(reqs); i++ {
+		out.Errors[i] = ctx.Err()
+	}
+
+	failed := 0
+	byURL := make(map[string]int)
+	for i, resp := range out.Searches {
+		if out.Errors[i] != nil {
+			failed++
+			continue
+		}
+		fused := make(map[string]bool)
+		for rank, res := range resp.Results {
+			key := CanonicalURL(res.URL)
+			j, ok := byURL[key]
+			if !ok {
+				j = len(out.Results)
+				byURL[key] = j
+				out.Results = append(out.Results, MultiSearchResult{
+					ParallelResult: ParallelResult{URL: res.URL, Title: res.Title},
+					CanonicalURL:   key,
+				})
+			}
+			merged := &out.Results[j]
+			if merged.Title == "" {
+				merged.Title = res.Title
+			}
+			for _, ex := range res.Excerpts {
+				if !slices.Contains(merged.Excerpts, ex) {
+					merged.Excerpts = append(merged.Excerpts, ex)
+				}
+			}
+			// A URL listed more than once by one search counts once, at its best rank.
+			if fused[key] {
+				continue
+			}
+			fused[key] = true
+			merged.Score += 1 / float64(k+rank+1)
+			merged.Sources = append(merged.Sources, SearchSource{Request: i, Rank: rank + 1})
+		}
+	}
+	if failed > 0 && failed == len(reqs) {
+		return out, errors.Join(out.Errors...)
+	}
+
+	slices.SortStableFunc(out.Results, func(a, b MultiSearchResult) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if opts.MaxResults > 0 && len(out.Results) > opts.MaxResults {
+		out.Results = out.Results[:opts.MaxResults]
+	}
+	return out, nil
+}
+
+// trackingParams are query parameters dropped by CanonicalURL, besides utm_*.
+var trackingParams = []string{"gclid", "fbclid", "msclkid", "dclid", "mc_cid", "mc_eid"}
+
+// CanonicalURL normalizes raw for deduplication: the scheme becomes https,
+// the host is lowercased and default ports, fragments, trailing slashes and
+// tracking parameters (utm_*, gclid, fbclid, ...) are removed, and the
+// remaining query parameters are sorted. Unparseable input is returned as is.
+func CanonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	if s := strings.ToLower(u.Scheme); s == "http" || s == "https" {
+		u.Scheme = "https"
+	}
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	switch {
+	case port != "" && port != "80" && port != "443":
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	u.Fragment, u.RawFragment = "", ""
+	// Trim the escaped form so that escapes such as %2F stay distinct from "/".
+	escaped := strings.TrimRight(u.EscapedPath(), "/")
+	if path, err := url.PathUnescape(escaped); err == nil {
+		u.Path, u.RawPath = path, escaped
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || slices.Contains(trackingParams, strings.ToLower(key)) {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.ForceQuery = false
+	return u.String()
+}
@@ -0,0 +1,87 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestMultiSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelSearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		var results []ParallelResult
+		switch req.Objective {
+		case "first":
+			results = []ParallelResult{
+				{URL: "https://a.example/page/?utm_source=x", Title: "A", Excerpts: []string{"a1"}},
+				{URL: "https://b.example/", Title: "B"},
+			}
+		case "second":
+			results = []ParallelResult{
+				{URL: "https://c.example/", Title: "C"},
+				{URL: "http://A.example/page#top", Excerpts: []string{"a1", "a2"}},
+				{URL: "https://a.example/page", Excerpts: []string{"a3"}},
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: req.Objective, Results: results})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	reqs := []ParallelSearchRequest{{Objective: "first"}, {Objective: "second"}, {Objective: "broken"}}
+
+	resp, err := client.MultiSearch(context.Background(), reqs, MultiSearchOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !errors.Is(resp.Errors[2], ErrInvalidRequest) || resp.Searches[2] != nil {
+		t.Errorf("Expected the third search to fail, got %v", resp.Errors[2])
+	}
+
+	var got []string
+	for _, res := range resp.Results {
+		got = append(got, res.CanonicalURL)
+	}
+	want := []string{"https://a.example/page", "https://c.example", "https://b.example"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Expected results %v, got %v", want, got)
+	}
+
+	top := resp.Results[0]
+	if top.URL != "https://a.example/page/?utm_source=x" || top.Title != "A" {
+		t.Errorf("Expected first-seen URL and title, got %q %q", top.URL, top.Title)
+	}
+	if !slices.Equal(top.Excerpts, []string{"a1", "a2", "a3"}) {
+		t.Errorf("Expected merged excerpts, got %v", top.Excerpts)
+	}
+	if want := []SearchSource{{Request: 0, Rank: 1}, {Request: 1, Rank: 2}}; !slices.Equal(top.Sources, want) {
+		t.Errorf("Expected sources %v, got %v", want, top.Sources)
+	}
+	if want := 1.0/61 + 1.0/62; math.Abs(top.Score-want) > 1e-12 {
+		t.Errorf("Expected score %v, got %v", want, top.Score)
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := map[string]string{
+		"HTTP://Example.COM:80/a/?b=2&a=1&utm_medium=x&gclid=1#frag": "https://example.com/a?a=1&b=2",
+		"https://example.com/":                  "https://example.com",
+		"https://example.com:8443/x?fbclid=abc": "https://example.com:8443/x",
+		"https://[::1]:443/":                    "https://[::1]",
+		"not a url":                             "not a url",
+	}
+	for in, want := range tests {
+		if got := CanonicalURL(in); got != want {
+			t.Errorf("Expected CanonicalURL(%q) to be %q, got %q", in, want, got)
+		}
+	}
+}
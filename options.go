@@ -0,0 +1,106 @@
+// path: parallel/options.go
+package parallel
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Defaults used by NewClient when no option overrides them.
+const (
+	DefaultBaseURL   = "https://api.parallel.ai/v1beta"
+	DefaultBetaTag   = "search-extract-2025-10-10"
+	DefaultTimeout   = 30 * time.Second
+	DefaultUserAgent = "go-parallel"
+)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvAPIKey    = "PARALLEL_API_KEY"
+	EnvBaseURL   = "PARALLEL_BASE_URL"
+	EnvBetaTag   = "PARALLEL_BETA_TAG"
+	EnvTimeout   = "PARALLEL_TIMEOUT"
+	EnvUserAgent = "PARALLEL_USER_AGENT"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIKey sets the API key sent with every request.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBaseURL overrides the API base URL, e.g. to target staging or a local fake.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the underlying HTTP client. A nil client is ignored.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.client = hc
+		}
+	}
+}
+
+// WithBetaTag overrides the value of the parallel-beta header.
+func WithBetaTag(tag string) Option {
+	return func(c *Client) {
+		c.betaTag = tag
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP request.
+// It is applied to a copy of the HTTP client, so a client passed to
+// WithHTTPClient is never mutated.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = &d
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// NewClientFromEnv creates a client configured from PARALLEL_* environment
+// variables. Options passed explicitly take precedence over the environment.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	var envOpts []Option
+
+	apiKey := os.Getenv(EnvAPIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s is not set", EnvAPIKey)
+	}
+	envOpts = append(envOpts, WithAPIKey(apiKey))
+
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		envOpts = append(envOpts, WithBaseURL(v))
+	}
+	if v := os.Getenv(EnvBetaTag); v != "" {
+		envOpts = append(envOpts, WithBetaTag(v))
+	}
+	if v := os.Getenv(EnvUserAgent); v != "" {
+		envOpts = append(envOpts, WithUserAgent(v))
+	}
+	if v := os.Getenv(EnvTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", EnvTimeout, err)
+		}
+		envOpts = append(envOpts, WithTimeout(d))
+	}
+
+	return NewClient(append(envOpts, opts...)...), nil
+}
@@ -0,0 +1,90 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	client := NewClient(
+		WithAPIKey("key"),
+		WithBaseURL("http://localhost:8080"),
+		WithBetaTag("custom-beta"),
+		WithHTTPClient(hc),
+		WithTimeout(5*time.Second),
+		WithUserAgent("my-app/1.0"),
+	)
+
+	if client.apiKey != "key" {
+		t.Errorf("Expected apiKey to be key, got %s", client.apiKey)
+	}
+	if client.baseURL != "http://localhost:8080" {
+		t.Errorf("Expected baseURL to be http://localhost:8080, got %s", client.baseURL)
+	}
+	if client.betaTag != "custom-beta" {
+		t.Errorf("Expected betaTag to be custom-beta, got %s", client.betaTag)
+	}
+	if client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout to be 5s, got %s", client.client.Timeout)
+	}
+	if hc.Timeout != time.Minute {
+		t.Errorf("Expected caller's http.Client to be left untouched, got timeout %s", hc.Timeout)
+	}
+}
+
+func TestNewClientNilHTTPClient(t *testing.T) {
+	client := NewClient(WithHTTPClient(nil), WithTimeout(5*time.Second))
+	if client.client == nil || client.client.Timeout != 5*time.Second {
+		t.Errorf("Expected nil http.Client to fall back to the default, got %+v", client.client)
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "env-key" {
+			t.Errorf("Expected x-api-key header to be 'env-key', got %s", r.Header.Get("x-api-key"))
+		}
+		if r.Header.Get("parallel-beta") != "env-beta" {
+			t.Errorf("Expected parallel-beta header to be 'env-beta', got %s", r.Header.Get("parallel-beta"))
+		}
+		if r.Header.Get("User-Agent") != "env-agent" {
+			t.Errorf("Expected User-Agent header to be 'env-agent', got %s", r.Header.Get("User-Agent"))
+		}
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "env-search"})
+	}))
+	defer server.Close()
+
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvBaseURL, server.URL)
+	t.Setenv(EnvBetaTag, "env-beta")
+	t.Setenv(EnvUserAgent, "env-agent")
+	t.Setenv(EnvTimeout, "2s")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.client.Timeout != 2*time.Second {
+		t.Errorf("Expected timeout to be 2s, got %s", client.client.Timeout)
+	}
+
+	resp, err := client.Search(context.Background(), ParallelSearchRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.SearchID != "env-search" {
+		t.Errorf("Expected SearchID to be 'env-search', got %s", resp.SearchID)
+	}
+}
+
+func TestNewClientFromEnvMissingKey(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Fatal("Expected an error when PARALLEL_API_KEY is unset, got nil")
+	}
+}
@@ -2,230 +2,151 @@
 package parallel
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 )
 
 // Client is the core Parallel API client.
 type Client struct {
-	baseURL string
-	apiKey  string
-	client  *http.Client
-	betaTag string
+	baseURL       string
+	apiKey        string
+	client        *http.Client
+	betaTag       string
+	userAgent     string
+	timeout       *time.Duration
+	retry         RetryPolicy
+	taskEvents    bool
+	cancelOnAbort bool
+	cache         Cache
+	cacheTTL      time.Duration
+	flights       *flightGroup
+	middleware    []Middleware
+	handler       Handler
 }
 
-// NewClient creates a new Parallel API client with defaults.
-func NewClient(apiKey string) *Client {
-	return &Client{
-		baseURL: "https://api.parallel.ai/v1beta",
-		apiKey:  apiKey,
+// NewClient creates a new Parallel API client with defaults, adjusted by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL: DefaultBaseURL,
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
-		betaTag: "search-extract-2025-10-10",
+		betaTag:   DefaultBetaTag,
+		userAgent: DefaultUserAgent,
+		retry:     DefaultRetryPolicy,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.timeout != nil {
+		hc := *c.client
+		hc.Timeout = *c.timeout
+		c.client = &hc
+	}
+	c.handler = c.buildHandler()
+	return c
 }
 
 // Search performs a semantic search query using the Parallel API.
+// The request is validated locally first; see ParallelSearchRequest.Validate.
+// With WithCache, responses may be served from the cache.
 func (c *Client) Search(ctx context.Context, req ParallelSearchRequest) (*ParallelSearchResponse, error) {
-	url := fmt.Sprintf("%s/search", c.baseURL)
-
-	payload, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("parallel-beta", c.betaTag)
-
-	res, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("API error: %s — %s", res.Status, string(body))
-	}
-
-	var out ParallelSearchResponse
-	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return &out, nil
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return do[ParallelSearchResponse](ctx, c, &Call{
+		Operation:  OpSearch,
+		Method:     http.MethodPost,
+		Path:       "/search",
+		Request:    &req,
+		idempotent: true,
+		cacheable:  true,
+	})
 }
 
 // RunTask launches a processing task (e.g., research, summarization, report generation).
+// The request is validated locally first; see ParallelTaskRequest.Validate.
 func (c *Client) RunTask(ctx context.Context, req ParallelTaskRequest) (*ParallelTaskResponse, error) {
-	url := fmt.Sprintf("%s/tasks/runs", c.baseURL)
-
-	payload, err := json.Marshal(req)
+	call, err := c.runTaskCall(&req)
 	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+		return nil, err
 	}
+	return do[ParallelTaskResponse](ctx, c, call)
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+// runTaskCall validates req and builds the Call shared by RunTask and RunTaskTyped.
+func (c *Client) runTaskCall(req *ParallelTaskRequest) (*Call, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("parallel-beta", c.betaTag)
-
-	res, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+	call := &Call{
+		Operation: OpRunTask,
+		Method:    http.MethodPost,
+		Path:      "/tasks/runs",
+		Header:    make(http.Header),
+		Request:   req,
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("API error: %s — %s", res.Status, string(body))
-	}
-
-	var out ParallelTaskResponse
-	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if req.Webhook != nil {
+		call.Header.Set("parallel-beta", c.betaTag+","+WebhookBetaTag)
 	}
-
-	return &out, nil
+	return call, nil
 }
 
 // GetTask retrieves the latest status or final output of a task run.
 func (c *Client) GetTask(ctx context.Context, runID string) (*ParallelTaskResult, error) {
-	url := fmt.Sprintf("%s/tasks/runs/%s", c.baseURL, runID)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	req.Header.Set("x-api-key", c.apiKey)
-	req.Header.Set("parallel-beta", c.betaTag)
-
-	res, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("API error: %s — %s", res.Status, string(b))
-	}
-
-	var out ParallelTaskResult
-	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return &out, nil
+	return do[ParallelTaskResult](ctx, c, &Call{
+		Operation:  OpGetTask,
+		Method:     http.MethodGet,
+		Path:       fmt.Sprintf("/tasks/runs/%s", runID),
+		Request:    &runID,
+		idempotent: true,
+	})
 }
 
-// PollUntilComplete continuously checks a task until its status is "completed" or context is canceled.
+// PollUntilComplete continuously checks a task until it reaches a terminal status or context is canceled.
+// The first check happens after at most DefaultPollInitialDelay, then every interval.
+// If the run ends unsuccessfully, a *TaskFailedError is returned.
+// With WithTaskEventStreaming it follows the run's event stream instead, when available.
+// With WithCancelOnAbort the run is cancelled if ctx is done first.
+// Use PollUntilCompleteWithOptions for adaptive intervals and progress callbacks.
 func (c *Client) PollUntilComplete(ctx context.Context, runID string, interval time.Duration) (*ParallelTaskResult, error) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-ticker.C:
-			task, err := c.GetTask(ctx, runID)
-			if err != nil {
-				return nil, err
-			}
-			if task.Status == "completed" || task.Status == "failed" {
-				return task, nil
-			}
-		}
-	}
+	return c.PollUntilCompleteWithOptions(ctx, runID, PollOptions{
+		InitialDelay: min(interval, DefaultPollInitialDelay),
+		Interval:     interval,
+	})
 }
 
 // Chat sends a chat completion request to Parallel's /chat/completions API.
+// If req.Stream is set, the streamed chunks are accumulated into one response;
+// use ChatStream to consume them incrementally.
 func (c *Client) Chat(ctx context.Context, req ParallelChatRequest) (*ParallelChatResponse, error) {
-	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-
-	res, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("API error: %s — %s", res.Status, string(b))
-	}
-
-	var out ParallelChatResponse
-	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return &out, nil
+	if req.Stream {
+		return c.collectChatStream(ctx, req)
+	}
+	return do[ParallelChatResponse](ctx, c, &Call{
+		Operation:  OpChat,
+		Method:     http.MethodPost,
+		Path:       "/chat/completions",
+		Request:    &req,
+		bearerAuth: true,
+	})
 }
 
 // Extract performs an extraction request on given URLs.
+// The request is validated locally first; see ParallelExtractRequest.Validate.
+// With WithCache, responses may be served from the cache.
 func (c *Client) Extract(ctx context.Context, req ParallelExtractRequest) (*ParallelExtractResponse, error) {
-	url := fmt.Sprintf("%s/extract", c.baseURL)
-
-	payload, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("parallel-beta", c.betaTag)
-
-	res, err := c.client.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("API error: %s — %s", res.Status, string(body))
-	}
-
-	var out ParallelExtractResponse
-	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return &out, nil
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return do[ParallelExtractResponse](ctx, c, &Call{
+		Operation:  OpExtract,
+		Method:     http.MethodPost,
+		Path:       "/extract",
+		Request:    &req,
+		idempotent: true,
+		cacheable:  true,
+	})
 }
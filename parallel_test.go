@@ -4,6 +4,7 @@ package parallel
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,7 +14,7 @@ import (
 
 func TestNewClient(t *testing.T) {
 	apiKey := "test-api-key"
-	client := NewClient(apiKey)
+	client := NewClient(WithAPIKey(apiKey))
 
 	if client.apiKey != apiKey {
 		t.Errorf("Expected apiKey to be %s, got %s", apiKey, client.apiKey)
@@ -49,8 +50,7 @@ func TestSearch(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	req := ParallelSearchRequest{
 		Objective: "test objective",
@@ -92,8 +92,7 @@ func TestExtract(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	req := ParallelExtractRequest{
 		URLs: []string{"https://example.com"},
@@ -142,8 +141,7 @@ func TestRunTask(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	req := ParallelTaskRequest{
 		Input: "test input",
@@ -172,8 +170,7 @@ func TestGetTask(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	resp, err := client.GetTask(context.Background(), "test-run-id")
 	if err != nil {
@@ -192,9 +189,9 @@ func TestPollUntilComplete(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		callCount++
-		status := "running"
+		status := TaskStatusRunning
 		if callCount > 2 {
-			status = "completed"
+			status = TaskStatusCompleted
 		}
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(ParallelTaskResult{
@@ -204,8 +201,7 @@ func TestPollUntilComplete(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	resp, err := client.PollUntilComplete(context.Background(), "test-run-id", 10*time.Millisecond)
 	if err != nil {
@@ -243,8 +239,7 @@ func TestChat(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	req := ParallelChatRequest{
 		Model: "test-model",
@@ -281,8 +276,7 @@ func TestAPIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient("test-api-key")
-	client.baseURL = server.URL + "/v1beta"
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
 
 	_, err := client.Search(context.Background(), ParallelSearchRequest{})
 	if err == nil {
@@ -293,4 +287,16 @@ func TestAPIError(t *testing.T) {
 	if err.Error() != expectedError {
 		t.Errorf("Expected error message '%s', got '%s'", expectedError, err.Error())
 	}
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected errors.Is(err, ErrUnauthorized) to be true")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected StatusCode to be 401, got %d", apiErr.StatusCode)
+	}
 }
@@ -0,0 +1,220 @@
+// path: parallel/pipeline.go
+package parallel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Operation names reported in Call.Operation.
+const (
+	OpSearch            = "search"
+	OpExtract           = "extract"
+	OpRunTask           = "run_task"
+	OpGetTask           = "get_task"
+	OpChat              = "chat"
+	OpChatStream        = "chat_stream"
+	OpTaskEvents        = "task_events"
+	OpGetTaskResult     = "get_task_result"
+	OpCancelTask        = "cancel_task"
+	OpCreateTaskGroup   = "create_task_group"
+	OpGetTaskGroup      = "get_task_group"
+	OpAddTaskGroupRuns  = "add_task_group_runs"
+	OpListTaskGroupRuns = "list_task_group_runs"
+	OpTaskGroupEvents   = "task_group_events"
+)
+
+// Call describes one Parallel API operation as it travels through the middleware chain.
+type Call struct {
+	Operation string      // one of the Op* constants
+	Method    string      // HTTP method
+	Path      string      // path relative to the client's base URL
+	Header    http.Header // extra headers applied on top of the client's defaults
+	Request   any         // typed request, e.g. *ParallelSearchRequest; middleware may mutate it
+	Response  any         // typed response, e.g. *ParallelSearchResponse; filled in once next returns
+
+	bearerAuth bool // authenticate with "Authorization: Bearer" instead of x-api-key
+	idempotent bool // safe to retry after the server may have seen the request
+	cacheable  bool // the response may be served from and stored in the client's Cache
+	longPoll   bool // held open by the server; bounded by the context instead of the client timeout
+}
+
+// Handler executes a Call.
+type Handler func(ctx context.Context, call *Call) error
+
+// Middleware wraps a Handler with cross-cutting behavior such as logging,
+// metrics, header injection or request mutation.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the client's chain. The first
+// middleware given is the outermost one.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// buildHandler composes the client's middleware around send.
+func (c *Client) buildHandler() Handler {
+	h := Handler(c.send)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// do runs call through the middleware chain and returns its typed response.
+func do[Resp any](ctx context.Context, c *Client, call *Call) (*Resp, error) {
+	if call.Header == nil {
+		call.Header = make(http.Header)
+	}
+	call.Response = new(Resp)
+
+	if err := c.handler(ctx, call); err != nil {
+		return nil, err
+	}
+
+	out, ok := call.Response.(*Resp)
+	if !ok {
+		return nil, fmt.Errorf("%s: middleware replaced response with %T", call.Operation, call.Response)
+	}
+	return out, nil
+}
+
+// fetchFunc obtains the raw response body for a call.
+type fetchFunc func(ctx context.Context, call *Call) ([]byte, error)
+
+// send is the innermost Handler: it fetches the response body, from the
+// client's Cache or a coalesced request where allowed, and decodes it.
+func (c *Client) send(ctx context.Context, call *Call) error {
+	fetch := fetchFunc(c.fetch)
+	if c.flights != nil && call.idempotent {
+		fetch = c.fetchShared
+	}
+	if call.cacheable {
+		next := fetch
+		fetch = func(ctx context.Context, call *Call) ([]byte, error) {
+			return c.fetchCached(ctx, call, next)
+		}
+	}
+	body, err := fetch(ctx, call)
+	if err != nil || body == nil {
+		return err
+	}
+	if err := json.Unmarshal(body, call.Response); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// fetch performs the HTTP exchange, retrying according to the client's
+// RetryPolicy, and returns the response body. For streaming calls it hands
+// the open body to the *streamBody response instead and returns nil.
+func (c *Client) fetch(ctx context.Context, call *Call) ([]byte, error) {
+	httpReq, err := c.newHTTPRequest(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && httpReq.GetBody != nil {
+			if httpReq.Body, err = httpReq.GetBody(); err != nil {
+				return nil, fmt.Errorf("create request: %w", err)
+			}
+		}
+
+		res, err := c.httpClient(call).Do(httpReq)
+		if err != nil {
+			if attempt >= c.retry.MaxAttempts || !retryableError(ctx, err, call.idempotent) {
+				return nil, &TransportError{Err: err}
+			}
+			if err := sleep(ctx, c.retry.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			b, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+			if attempt >= c.retry.MaxAttempts || !retryableStatus(res.StatusCode, call.idempotent) {
+				return nil, newAPIError(res, b)
+			}
+			wait, ok := retryAfter(res.Header)
+			if !ok {
+				wait = c.retry.backoff(attempt)
+			} else if !c.retry.allowsWait(wait) {
+				return nil, newAPIError(res, b)
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if stream, ok := call.Response.(*streamBody); ok {
+			stream.ReadCloser = res.Body
+			return nil, nil
+		}
+
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, &TransportError{Err: err}
+		}
+		return body, nil
+	}
+}
+
+// httpClient returns the HTTP client for call. Streaming and long-poll calls
+// use a copy without the overall timeout, which would otherwise cut them
+// short; they are bounded by the caller's context instead.
+func (c *Client) httpClient(call *Call) *http.Client {
+	_, stream := call.Response.(*streamBody)
+	if (stream || call.longPoll) && c.client.Timeout != 0 {
+		hc := *c.client
+		hc.Timeout = 0
+		return &hc
+	}
+	return c.client
+}
+
+// newHTTPRequest builds the HTTP request for call, including auth and extra headers.
+func (c *Client) newHTTPRequest(ctx context.Context, call *Call) (*http.Request, error) {
+	var body io.Reader
+	if call.Request != nil && call.Method != http.MethodGet {
+		payload, err := json.Marshal(call.Request)
+		if err != nil {
+			return nil, &EncodeError{Err: err}
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, call.Method, c.baseURL+call.Path, body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if call.bearerAuth {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	} else {
+		httpReq.Header.Set("x-api-key", c.apiKey)
+		httpReq.Header.Set("parallel-beta", c.betaTag)
+	}
+	for k, vs := range call.Header {
+		httpReq.Header.Del(k)
+		for _, v := range vs {
+			httpReq.Header.Add(k, v)
+		}
+	}
+
+	return httpReq, nil
+}
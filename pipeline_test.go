@@ -0,0 +1,64 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace-123" {
+			t.Errorf("Expected X-Trace-Id header to be 'trace-123', got %s", r.Header.Get("X-Trace-Id"))
+		}
+		var req ParallelSearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.MaxResults != 3 {
+			t.Errorf("Expected middleware to set MaxResults to 3, got %d", req.MaxResults)
+		}
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "test-search-id"})
+	}))
+	defer server.Close()
+
+	var trace []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, call *Call) error {
+				trace = append(trace, name+":before:"+call.Operation)
+				err := next(ctx, call)
+				trace = append(trace, name+":after:"+call.Response.(*ParallelSearchResponse).SearchID)
+				return err
+			}
+		}
+	}
+	mutate := func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) error {
+			call.Header.Set("X-Trace-Id", "trace-123")
+			call.Request.(*ParallelSearchRequest).MaxResults = 3
+			return next(ctx, call)
+		}
+	}
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL+"/v1beta"),
+		WithMiddleware(record("outer"), record("inner"), mutate),
+	)
+
+	if _, err := client.Search(context.Background(), ParallelSearchRequest{Objective: "test objective"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"outer:before:search",
+		"inner:before:search",
+		"inner:after:test-search-id",
+		"outer:after:test-search-id",
+	}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("Expected middleware trace %v, got %v", expected, trace)
+	}
+}
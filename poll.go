@@ -0,0 +1,101 @@
+// path: parallel/poll.go
+package parallel
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultPollInterval is the interval used when PollOptions.Interval is zero.
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultPollInitialDelay bounds the wait before the first check made by
+// PollUntilComplete, so short tasks are not held back by a long interval.
+const DefaultPollInitialDelay = time.Second
+
+// PollOptions controls how PollUntilCompleteWithOptions checks a task run.
+type PollOptions struct {
+	InitialDelay  time.Duration             // wait before the first check; zero checks immediately
+	Interval      time.Duration             // wait after the first check; zero uses DefaultPollInterval
+	Multiplier    float64                   // growth factor applied to the interval after each check; values <= 1 keep it fixed
+	MaxInterval   time.Duration             // upper bound for the interval; zero means unbounded
+	Jitter        float64                   // randomizes each wait by up to ±Jitter of its length, e.g. 0.1
+	OnUpdate      func(*ParallelTaskResult) // called on the first observation and on every status change
+	CancelOnAbort bool                      // cancel the run if ctx is done first, even without WithCancelOnAbort
+}
+
+// next returns the interval following d.
+func (o PollOptions) next(d time.Duration) time.Duration {
+	if o.Multiplier > 1 {
+		d = time.Duration(float64(d) * o.Multiplier)
+	}
+	if o.MaxInterval > 0 && d > o.MaxInterval {
+		d = o.MaxInterval
+	}
+	return d
+}
+
+// jitter randomizes d by up to ±Jitter of its length.
+func (o PollOptions) jitter(d time.Duration) time.Duration {
+	if o.Jitter <= 0 || d <= 0 {
+		return d
+	}
+	spread := float64(d) * min(o.Jitter, 1)
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// PollUntilCompleteWithOptions checks a task until it reaches a terminal
+// status or ctx is done, with an adaptive interval and progress callbacks.
+// If the run ends unsuccessfully, a *TaskFailedError is returned.
+func (c *Client) PollUntilCompleteWithOptions(ctx context.Context, runID string, opts PollOptions) (*ParallelTaskResult, error) {
+	task, err := c.poll(ctx, runID, opts)
+	if err != nil {
+		c.cancelIfAborted(ctx, runID, c.cancelOnAbort || opts.CancelOnAbort)
+	}
+	return task, err
+}
+
+func (c *Client) poll(ctx context.Context, runID string, opts PollOptions) (*ParallelTaskResult, error) {
+	var last TaskStatus
+	update := func(task *ParallelTaskResult) {
+		if opts.OnUpdate != nil && task.Status != last {
+			last = task.Status
+			opts.OnUpdate(task)
+		}
+	}
+
+	if c.taskEvents {
+		task, err := c.pollViaEvents(ctx, runID, update)
+		if err == nil || !eventsUnavailable(err) {
+			return task, err
+		}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	if opts.MaxInterval > 0 {
+		interval = min(interval, opts.MaxInterval)
+	}
+
+	wait := opts.InitialDelay
+	for {
+		if err := sleep(ctx, opts.jitter(wait)); err != nil {
+			return nil, err
+		}
+
+		task, err := c.GetTask(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+		update(task)
+		if task.finished() {
+			return checkFinished(task)
+		}
+
+		wait = interval
+		interval = opts.next(interval)
+	}
+}
@@ -0,0 +1,87 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPollUntilCompleteWithOptions(t *testing.T) {
+	statuses := []TaskStatus{TaskStatusQueued, TaskStatusRunning, TaskStatusRunning, TaskStatusCompleted}
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(callCount, len(statuses)-1)]
+		callCount++
+		json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id", Status: status, IsActive: boolPtr(!status.IsTerminal())})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	var updates []TaskStatus
+	start := time.Now()
+	resp, err := client.PollUntilCompleteWithOptions(context.Background(), "test-run-id", PollOptions{
+		Interval:    time.Millisecond,
+		Multiplier:  2,
+		MaxInterval: 3 * time.Millisecond,
+		Jitter:      0.1,
+		OnUpdate: func(task *ParallelTaskResult) {
+			updates = append(updates, task.Status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Status != TaskStatusCompleted {
+		t.Errorf("Expected final status to be 'completed', got %s", resp.Status)
+	}
+	if callCount != 4 {
+		t.Errorf("Expected GetTask to be called 4 times, got %d", callCount)
+	}
+	expected := []TaskStatus{TaskStatusQueued, TaskStatusRunning, TaskStatusCompleted}
+	if !reflect.DeepEqual(updates, expected) {
+		t.Errorf("Expected updates %v, got %v", expected, updates)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected short intervals, polling took %s", elapsed)
+	}
+}
+
+func TestPollOptionsNext(t *testing.T) {
+	opts := PollOptions{Multiplier: 2, MaxInterval: 5 * time.Second}
+
+	var got []time.Duration
+	d := time.Second
+	for range 4 {
+		d = opts.next(d)
+		got = append(got, d)
+	}
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected intervals %v, got %v", expected, got)
+	}
+}
+
+func TestPollUntilCompleteFirstCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id", Status: TaskStatusCompleted})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.PollUntilComplete(ctx, "test-run-id", time.Hour); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > DefaultPollInitialDelay+time.Second {
+		t.Errorf("Expected the first check within %s, took %s", DefaultPollInitialDelay, elapsed)
+	}
+}
@@ -0,0 +1,312 @@
+// path: parallel/replay/replay.go
+
+// Package replay provides an http.RoundTripper that records HTTP exchanges to
+// a cassette file and replays them later, so tests against the Parallel API
+// can run offline and deterministically.
+//
+// Plug it into a client with parallel.WithHTTPClient, and call Save once the
+// exchanges are recorded:
+//
+//	tr, err := replay.New("testdata/search.json", mode)
+//	client := parallel.NewClient(parallel.WithHTTPClient(&http.Client{Transport: tr}))
+//	defer tr.Save()
+//
+// Requests are matched on method, path, query and body; JSON bodies are
+// compared after normalization, so key order and whitespace do not matter.
+// Identical requests are answered in recorded order, and the last answer is
+// repeated once they run out, which suits polling loops. Credentials in the
+// x-api-key, Authorization and Cookie request headers and the Set-Cookie
+// response header are never written to a cassette.
+//
+// A request with no recorded interaction fails with ErrNoInteraction as a
+// transport error, which a parallel.Client retries like any other for
+// idempotent calls. Use parallel.RetryPolicy{MaxAttempts: 1} in replayed
+// tests to fail on the first miss.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mode selects what a Transport does with requests.
+type Mode int
+
+const (
+	ModeReplay      Mode = iota // answer from the cassette; unmatched requests fail
+	ModeRecord                  // forward requests and record them to a new cassette
+	ModePassthrough             // forward requests without recording
+)
+
+// Redacted replaces the values of redacted headers in cassettes.
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned in ModeReplay when no recorded exchange matches a request.
+var ErrNoInteraction = errors.New("replay: no recorded interaction matches request")
+
+// Cassette is the file format of recorded exchanges.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Request and response headers that are always redacted.
+var (
+	defaultRedactHeaders         = []string{"x-api-key", "Authorization", "Cookie"}
+	defaultRedactResponseHeaders = []string{"Set-Cookie"}
+)
+
+// Transport is an http.RoundTripper that records or replays exchanges.
+type Transport struct {
+	// Next performs real requests in ModeRecord and ModePassthrough.
+	// If nil, http.DefaultTransport is used.
+	Next http.RoundTripper
+	// RedactHeaders lists request headers to redact in addition to
+	// x-api-key, Authorization and Cookie.
+	RedactHeaders []string
+	// RedactResponseHeaders lists response headers to redact in addition
+	// to Set-Cookie.
+	RedactResponseHeaders []string
+
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // in ModeReplay, whether each interaction has been served
+}
+
+// New returns a Transport using the cassette at path. In ModeReplay the
+// cassette is loaded and must exist; in ModeRecord it is replaced by the
+// exchanges recorded from now on, once Save is called.
+func New(path string, mode Mode) (*Transport, error) {
+	t := &Transport{mode: mode, path: path}
+	switch mode {
+	case ModeReplay:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("replay: load cassette: %w", err)
+		}
+		if err := json.Unmarshal(b, &t.cassette); err != nil {
+			return nil, fmt.Errorf("replay: decode cassette %s: %w", path, err)
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	case ModeRecord, ModePassthrough:
+	default:
+		return nil, fmt.Errorf("replay: unknown mode %d", mode)
+	}
+	return t, nil
+}
+
+// ParseMode parses "replay", "record" or "passthrough", e.g. from an
+// environment variable that switches tests between recording and replaying.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "", "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "passthrough":
+		return ModePassthrough, nil
+	}
+	return 0, fmt.Errorf("replay: unknown mode %q", s)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.mode {
+	case ModePassthrough:
+		return t.next().RoundTrip(req)
+	case ModeRecord:
+		return t.record(req)
+	}
+	return t.replay(req)
+}
+
+func (t *Transport) next() http.RoundTripper {
+	if t.Next != nil {
+		return t.Next
+	}
+	return http.DefaultTransport
+}
+
+// replay answers req from the first unused matching interaction, or the
+// last matching one if all have been used.
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := matchKey(req.Method, req.URL, body)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	found := -1
+	for i, in := range t.cassette.Interactions {
+		u, err := url.Parse(in.Request.URL)
+		if err != nil || matchKey(in.Request.Method, u, []byte(in.Request.Body)) != key {
+			continue
+		}
+		found = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+	}
+	t.used[found] = true
+	return newResponse(req, t.cassette.Interactions[found].Response), nil
+}
+
+// record forwards req and appends the exchange to the cassette in memory.
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+
+	res, err := t.next().RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	in := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: redact(req.Header, defaultRedactHeaders, t.RedactHeaders),
+			Body:   string(body),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     redact(res.Header, defaultRedactResponseHeaders, t.RedactResponseHeaders),
+			Body:       string(resBody),
+		},
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, in)
+	t.mu.Unlock()
+
+	// The caller gets the real headers; only the cassette is redacted.
+	live := in.Response
+	live.Header = res.Header
+	return newResponse(req, live), nil
+}
+
+// Save writes the exchanges recorded so far to the cassette file. It does
+// nothing outside ModeRecord.
+func (t *Transport) Save() error {
+	if t.mode != ModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	b, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("replay: save cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return fmt.Errorf("replay: save cassette: %w", err)
+	}
+	if err := os.WriteFile(t.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("replay: save cassette: %w", err)
+	}
+	return nil
+}
+
+// redact returns a copy of h with the named headers replaced by Redacted.
+func redact(h http.Header, defaults, extra []string) http.Header {
+	h = h.Clone()
+	for _, name := range append(slices.Clone(defaults), extra...) {
+		if h.Get(name) != "" {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+// readBody reads and closes the request body, if any.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// matchKey identifies a request by method, path, sorted query and normalized body.
+func matchKey(method string, u *url.URL, body []byte) string {
+	return strings.Join([]string{strings.ToUpper(method), u.Path, u.Query().Encode(), normalizeBody(body)}, "\n")
+}
+
+// normalizeBody re-encodes JSON bodies so that key order and whitespace do
+// not affect matching. Other bodies are compared as is.
+func normalizeBody(body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return string(bytes.TrimSpace(body))
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+func newResponse(req *http.Request, r Response) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,135 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	parallel "github.com/Raezil/go-parallel"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret-session"})
+		switch r.URL.Path {
+		case "/search":
+			var req parallel.ParallelSearchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(parallel.ParallelSearchResponse{SearchID: "search-" + req.Objective})
+		case "/tasks/runs/run_1":
+			polls++
+			status := parallel.TaskStatusRunning
+			if polls > 1 {
+				status = parallel.TaskStatusCompleted
+			}
+			json.NewEncoder(w).Encode(parallel.ParallelTaskResult{RunID: "run_1", Status: status})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	run := func(mode Mode) []string {
+		t.Helper()
+		tr, err := New(path, mode)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		client := parallel.NewClient(
+			parallel.WithAPIKey("secret-api-key"),
+			parallel.WithBaseURL(server.URL),
+			parallel.WithHTTPClient(&http.Client{Transport: tr}),
+		)
+		ctx := context.Background()
+
+		var got []string
+		for _, objective := range []string{"a", "b"} {
+			resp, err := client.Search(ctx, parallel.ParallelSearchRequest{Objective: objective})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			got = append(got, resp.SearchID)
+		}
+		for range 3 {
+			task, err := client.GetTask(ctx, "run_1")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			got = append(got, string(task.Status))
+		}
+		if err := tr.Save(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return got
+	}
+
+	recorded := run(ModeRecord)
+	server.Close()
+	replayed := run(ModeReplay)
+
+	want := "search-a search-b running completed completed"
+	if strings.Join(recorded, " ") != want || strings.Join(replayed, " ") != want {
+		t.Errorf("Expected %q when recording and replaying, got %q and %q", want, recorded, replayed)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected cassette to be written, got %v", err)
+	}
+	if strings.Contains(string(b), "secret-api-key") {
+		t.Errorf("Expected API key to be redacted from cassette")
+	}
+	if strings.Contains(string(b), "secret-session") {
+		t.Errorf("Expected Set-Cookie to be redacted from cassette")
+	}
+}
+
+func TestReplayMatching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: http.MethodPost, URL: "https://api.example/v1/extract?b=2&a=1", Body: `{"urls":["https://a.example"],"objective":"x"}`},
+		Response: Response{StatusCode: http.StatusOK, Body: `{"extract_id":"recorded"}`},
+	}}}
+	b, _ := json.Marshal(cassette)
+	os.WriteFile(path, b, 0o644)
+
+	tr, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client := &http.Client{Transport: tr}
+
+	body := `{ "objective": "x", "urls": ["https://a.example"] }`
+	res, err := client.Post("http://localhost/v1/extract?a=1&b=2", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Expected normalized body and query to match, got %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", res.StatusCode)
+	}
+
+	_, err = client.Post("http://localhost/v1/extract?a=1&b=2", "application/json", strings.NewReader(`{"objective":"y"}`))
+	if !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected ErrNoInteraction, got %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": ModeReplay, "record": ModeRecord, "Passthrough": ModePassthrough} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("Expected ParseMode(%q) to be %d, got %d (%v)", in, want, got, err)
+		}
+	}
+	if _, err := ParseMode("rewind"); err == nil {
+		t.Errorf("Expected an error for an unknown mode")
+	}
+}
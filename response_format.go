@@ -0,0 +1,20 @@
+// path: parallel/response_format.go
+package parallel
+
+import "github.com/Raezil/go-parallel/schema"
+
+// ResponseFormatFor builds a json_schema response format for Chat from the Go type T.
+// See the schema package for the supported struct tags.
+func ResponseFormatFor[T any](name string) (*ParallelResponseFormat, error) {
+	s, err := schema.For[T]()
+	if err != nil {
+		return nil, err
+	}
+	return &ParallelResponseFormat{
+		Type: "json_schema",
+		JSONSchema: ParallelResponseJSONSchemaSpec{
+			Name:   name,
+			Schema: s,
+		},
+	}, nil
+}
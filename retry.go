@@ -0,0 +1,113 @@
+// path: parallel/retry.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Idempotent operations (Search, Extract, GetTask) are retried on 429, 5xx
+// gateway errors and transport failures. Non-idempotent operations (RunTask,
+// Chat) are only retried when the server cannot have acted on the request:
+// a 429 response, or a failure to establish the connection.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; values <= 1 disable retries
+	BaseBackoff time.Duration // backoff before the first retry, doubled on each subsequent one
+	MaxBackoff  time.Duration // upper bound for a single backoff, including a server's Retry-After
+}
+
+// DefaultRetryPolicy is used by NewClient unless WithRetryPolicy overrides it.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: 500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// WithRetryPolicy sets the client's retry policy.
+// Pass RetryPolicy{MaxAttempts: 1} to disable retries.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// retryableStatus reports whether an HTTP status is worth retrying.
+// Only 429 is safe for non-idempotent calls: the request was rejected unprocessed.
+func retryableStatus(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is worth retrying.
+// Non-idempotent calls are only retried if the connection was never established.
+func retryableError(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if idempotent {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns the full-jitter delay before retry number attempt (starting at 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
+
+// allowsWait reports whether a server-requested wait fits the policy.
+// A Retry-After beyond MaxBackoff ends the retries instead of stalling the call.
+func (p RetryPolicy) allowsWait(d time.Duration) bool {
+	return p.MaxBackoff <= 0 || d <= p.MaxBackoff
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryIdempotent(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "test-search-id"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+	resp, err := client.Search(context.Background(), ParallelSearchRequest{Objective: "test objective"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.SearchID != "test-search-id" {
+		t.Errorf("Expected SearchID to be 'test-search-id', got %s", resp.SearchID)
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 attempts, got %d", callCount)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		expectedCalls int
+	}{
+		{"service unavailable is not retried", http.StatusServiceUnavailable, 1},
+		{"rate limited is retried", http.StatusTooManyRequests, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callCount++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+			if _, err := client.RunTask(context.Background(), ParallelTaskRequest{Input: "test input"}); err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			if callCount != tt.expectedCalls {
+				t.Errorf("Expected %d attempts, got %d", tt.expectedCalls, callCount)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "7")
+	if d, ok := retryAfter(h); !ok || d != 7*time.Second {
+		t.Errorf("Expected Retry-After of 7s, got %s (ok=%v)", d, ok)
+	}
+
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if d, ok := retryAfter(h); !ok || d < 59*time.Minute {
+		t.Errorf("Expected Retry-After of about 1h, got %s (ok=%v)", d, ok)
+	}
+}
+
+func TestRetryAfterBeyondMaxBackoff(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+	start := time.Now()
+	_, err := client.Search(context.Background(), ParallelSearchRequest{})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("Expected to give up without waiting, got %d calls in %s", calls, time.Since(start))
+	}
+}
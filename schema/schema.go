@@ -0,0 +1,406 @@
+// path: parallel/schema/schema.go
+
+// Package schema generates JSON Schema (draft 2020-12) from Go types.
+//
+// Field names and optionality follow encoding/json: the json tag sets the
+// property name, "-" skips the field, and omitempty or omitzero makes it
+// optional. Every other field is required. Additional struct tags refine the
+// field's schema:
+//
+//	description:"..."  human-readable description
+//	enum:"a,b,c"       allowed values, parsed according to the field's type
+//	format:"email"     string format, e.g. date, uri, email
+//	min:"1" max:"10"   minimum/maximum for numbers, minLength/maxLength for
+//	                   strings, minItems/maxItems for slices and arrays
+//
+// On slices and arrays, enum and format apply to the elements.
+// time.Time becomes a date-time string. Required pointer, slice and map
+// fields also accept null, which is how encoding/json writes their nil values. Fields promoted through an embedded
+// pointer are optional, since encoding/json omits them when it is nil. Recursive types are emitted once
+// under $defs and referenced with $ref.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the $schema URI of the emitted schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// For returns the JSON Schema for T.
+func For[T any]() (map[string]any, error) {
+	return Reflect(reflect.TypeFor[T]())
+}
+
+// Reflect returns the JSON Schema for t.
+func Reflect(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	g := &generator{
+		root:      t,
+		recursive: make(map[reflect.Type]bool),
+		names:     make(map[reflect.Type]string),
+		defs:      make(map[string]any),
+	}
+	g.findRecursive(t, make(map[reflect.Type]bool), make(map[reflect.Type]bool))
+
+	var (
+		out map[string]any
+		err error
+	)
+	if t.Kind() == reflect.Struct && t != timeType {
+		out, err = g.structSchema(t)
+	} else {
+		out, err = g.schemaFor(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out["$schema"] = Draft
+	if len(g.defs) > 0 {
+		out["$defs"] = g.defs
+	}
+	return out, nil
+}
+
+type generator struct {
+	root      reflect.Type
+	recursive map[reflect.Type]bool   // named structs that (indirectly) contain themselves
+	names     map[reflect.Type]string // $defs names of recursive types
+	defs      map[string]any
+}
+
+// findRecursive marks every struct type reachable from t that contains itself.
+func (g *generator) findRecursive(t reflect.Type, stack, done map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		g.findRecursive(t.Elem(), stack, done)
+	case reflect.Map:
+		g.findRecursive(t.Elem(), stack, done)
+	case reflect.Struct:
+		if stack[t] {
+			g.recursive[t] = true
+			return
+		}
+		if done[t] || t == timeType {
+			return
+		}
+		stack[t] = true
+		for _, f := range reflect.VisibleFields(t) {
+			if f.IsExported() || f.Anonymous {
+				g.findRecursive(f.Type, stack, done)
+			}
+		}
+		delete(stack, t)
+		done[t] = true
+	}
+}
+
+// schemaFor returns the schema of a value of type t.
+func (g *generator) schemaFor(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		out := map[string]any{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			out["minItems"], out["maxItems"] = t.Len(), t.Len()
+		}
+		return out, nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("schema: unsupported map key type %s", t.Key())
+		}
+		values, err := g.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if !g.recursive[t] {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	}
+	return nil, fmt.Errorf("schema: unsupported type %s", t)
+}
+
+// ref returns a $ref to a recursive type, generating its definition on first use.
+func (g *generator) ref(t reflect.Type) (map[string]any, error) {
+	if t == g.root {
+		return map[string]any{"$ref": "#"}, nil
+	}
+	if name, ok := g.names[t]; ok {
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+
+	name := g.defName(t)
+	g.names[t] = name
+	g.defs[name] = nil // reserve the name while the definition is generated
+	def, err := g.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[name] = def
+	return map[string]any{"$ref": "#/$defs/" + name}, nil
+}
+
+var unsafeDefChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// defName returns a unique $defs key for t.
+func (g *generator) defName(t reflect.Type) string {
+	base := unsafeDefChars.ReplaceAllString(t.Name(), "_")
+	if base == "" {
+		base = "def"
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := g.defs[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+// structSchema returns the inline object schema of struct type t.
+func (g *generator) structSchema(t reflect.Type) (map[string]any, error) {
+	properties := make(map[string]any)
+	required := []string{}
+
+	for _, f := range reflect.VisibleFields(t) {
+		if len(f.Index) > 1 && shadowed(t, f) {
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		name, optional, skip := jsonName(f)
+		if skip {
+			continue
+		}
+		if len(f.Index) > 1 && viaPointer(t, f) {
+			optional = true
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				continue // promoted fields are visited on their own
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := g.schemaFor(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), f.Name, err)
+		}
+		if err := applyTags(prop, f); err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), f.Name, err)
+		}
+		if !optional && nullable(f.Type) {
+			prop = allowNull(prop)
+		}
+		properties[name] = prop
+		if !optional {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+// shadowed reports whether a promoted field is hidden because one of the
+// embedding structs along its path is tagged with an explicit json name.
+func shadowed(t reflect.Type, f reflect.StructField) bool {
+	for i := 1; i < len(f.Index); i++ {
+		embed := t.FieldByIndex(f.Index[:i])
+		if name, _, skip := jsonName(embed); skip || name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// viaPointer reports whether a promoted field is reached through an embedded
+// pointer, which encoding/json skips when it is nil.
+func viaPointer(t reflect.Type, f reflect.StructField) bool {
+	for i := 1; i < len(f.Index); i++ {
+		if t.FieldByIndex(f.Index[:i]).Type.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable reports whether encoding/json writes the zero value of t as null.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// allowNull returns prop extended to also accept null.
+func allowNull(prop map[string]any) map[string]any {
+	if ref, ok := prop["$ref"]; ok {
+		return map[string]any{"anyOf": []any{map[string]any{"$ref": ref}, map[string]any{"type": "null"}}}
+	}
+	if t, ok := prop["type"].(string); ok {
+		prop["type"] = []any{t, "null"}
+	}
+	if enum, ok := prop["enum"].([]any); ok {
+		prop["enum"] = append(enum, nil)
+	}
+	return prop
+}
+
+// jsonName parses the json tag of f.
+func jsonName(f reflect.StructField) (name string, optional, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			optional = true
+		}
+	}
+	return name, optional, false
+}
+
+// applyTags refines prop with the description, enum, format, min and max tags of f.
+func applyTags(prop map[string]any, f reflect.StructField) error {
+	t := f.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if v, ok := f.Tag.Lookup("description"); ok {
+		prop["description"] = v
+	}
+	// format and enum describe the elements of slices and arrays.
+	target := prop
+	if items, ok := prop["items"].(map[string]any); ok {
+		target = items
+	}
+	if v, ok := f.Tag.Lookup("format"); ok {
+		target["format"] = v
+	}
+	if v, ok := f.Tag.Lookup("enum"); ok {
+		elem := t
+		if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array {
+			elem = elem.Elem()
+		}
+		var values []any
+		for s := range strings.SplitSeq(v, ",") {
+			value, err := parseValue(elem, strings.TrimSpace(s))
+			if err != nil {
+				return fmt.Errorf("enum: %w", err)
+			}
+			values = append(values, value)
+		}
+		target["enum"] = values
+	}
+
+	for _, bound := range []string{"min", "max"} {
+		v, ok := f.Tag.Lookup(bound)
+		if !ok {
+			continue
+		}
+		var key string
+		switch t.Kind() {
+		case reflect.String:
+			key = bound + "Length"
+		case reflect.Slice, reflect.Array:
+			key = bound + "Items"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			key = map[string]string{"min": "minimum", "max": "maximum"}[bound]
+		default:
+			return fmt.Errorf("%s tag is not supported on %s", bound, t)
+		}
+
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", bound, err)
+		}
+		if key == "minimum" || key == "maximum" {
+			prop[key] = n
+		} else {
+			prop[key] = int(n)
+		}
+	}
+	return nil
+}
+
+// parseValue parses an enum value for a field of type t.
+func parseValue(t reflect.Type, s string) (any, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseInt(s, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	}
+	return s, nil
+}
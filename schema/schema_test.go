@@ -0,0 +1,169 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City    string `json:"city" description:"City name"`
+	Country string `json:"country,omitempty" min:"2" max:"2"`
+}
+
+type base struct {
+	ID string `json:"id" format:"uuid"`
+}
+
+type person struct {
+	base
+	Name     string            `json:"name" min:"1"`
+	Age      int               `json:"age,omitempty" min:"0" max:"150"`
+	Role     string            `json:"role" enum:"admin,member"`
+	Tags     []string          `json:"tags,omitempty" max:"5" format:"hostname"`
+	Address  *address          `json:"address"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Birthday time.Time         `json:"birthday"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+type node struct {
+	Value    int     `json:"value"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type tree struct {
+	Root node `json:"root"`
+}
+
+func TestFor(t *testing.T) {
+	got, err := For[person]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["id", "name", "role", "address", "birthday"],
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"name": {"type": "string", "minLength": 1},
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"role": {"type": "string", "enum": ["admin", "member"]},
+			"tags": {"type": "array", "items": {"type": "string", "format": "hostname"}, "maxItems": 5},
+			"address": {
+				"type": ["object", "null"],
+				"additionalProperties": false,
+				"required": ["city"],
+				"properties": {
+					"city": {"type": "string", "description": "City name"},
+					"country": {"type": "string", "minLength": 2, "maxLength": 2}
+				}
+			},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"birthday": {"type": "string", "format": "date-time"}
+		}
+	}`
+	assertJSONEqual(t, got, expected)
+}
+
+func TestForRecursive(t *testing.T) {
+	got, err := For[tree]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["root"],
+		"properties": {
+			"root": {"$ref": "#/$defs/node"}
+		},
+		"$defs": {
+			"node": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["value"],
+				"properties": {
+					"value": {"type": "integer"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+				}
+			}
+		}
+	}`
+	assertJSONEqual(t, got, expected)
+
+	got, err = For[node]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	items := got["properties"].(map[string]any)["children"].(map[string]any)["items"]
+	if !reflect.DeepEqual(items, map[string]any{"$ref": "#"}) {
+		t.Errorf("Expected recursive root to be referenced as #, got %v", items)
+	}
+}
+
+func TestForUnsupported(t *testing.T) {
+	if _, err := For[struct {
+		C chan int `json:"c"`
+	}](); err == nil {
+		t.Fatal("Expected an error for a channel field, got nil")
+	}
+}
+
+func assertJSONEqual(t *testing.T, got map[string]any, expected string) {
+	t.Helper()
+	gotJSON, _ := json.Marshal(got)
+	var g, e any
+	json.Unmarshal(gotJSON, &g)
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	if !reflect.DeepEqual(g, e) {
+		want, _ := json.MarshalIndent(e, "", "  ")
+		have, _ := json.MarshalIndent(g, "", "  ")
+		t.Errorf("Schema mismatch\nexpected: %s\ngot:      %s", want, have)
+	}
+}
+
+type zeroable struct {
+	*base
+	Aliases []string            `json:"aliases"`
+	Scores  map[string]int      `json:"scores"`
+	Address *address            `json:"address"`
+	Next    *node               `json:"next"`
+	Level   *string             `json:"level" enum:"low,high"`
+	Raw     []byte              `json:"raw"`
+	Nested  map[string]*address `json:"nested"`
+}
+
+func TestForZeroValue(t *testing.T) {
+	s, err := For[zeroable]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := Validate(s, zeroable{}); err != nil {
+		t.Errorf("Expected the zero value to match its own schema, got %v", err)
+	}
+	required := s["required"].([]string)
+	if slices.Contains(required, "id") {
+		t.Errorf("Expected fields promoted through a nil embedded pointer to be optional, got required %v", required)
+	}
+
+	level := "high"
+	full := zeroable{base: &base{ID: "x"}, Aliases: []string{"a"}, Address: &address{City: "Paris"}, Next: &node{Value: 1}, Level: &level}
+	if err := Validate(s, full); err != nil {
+		t.Errorf("Expected a populated value to match, got %v", err)
+	}
+	level = "medium"
+	if err := Validate(s, full); err == nil {
+		t.Errorf("Expected an enum violation for level")
+	}
+}
@@ -0,0 +1,312 @@
+// path: parallel/schema/validate.go
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError reports the first place where a value does not match a schema.
+type ValidationError struct {
+	Path    string // location of the offending value, e.g. "address.city" or "tags[2]"; empty for the root
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks value against schema. Both are normalized through
+// encoding/json first, so value may be any JSON-encodable Go value and schema
+// may come from For or be written by hand.
+//
+// The supported keywords are type, enum, const, properties, required,
+// additionalProperties, items, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, minLength, maxLength, pattern, minItems, maxItems,
+// anyOf, oneOf, allOf and local $ref ("#" and "#/$defs/..."). Other
+// keywords, including format, are ignored.
+func Validate(schema map[string]any, value any) error {
+	var root map[string]any
+	if err := roundTrip(schema, &root); err != nil {
+		return fmt.Errorf("schema: normalize schema: %w", err)
+	}
+	var v any
+	if err := roundTrip(value, &v); err != nil {
+		return fmt.Errorf("schema: normalize value: %w", err)
+	}
+	return (&validator{root: root}).validate(root, v, "")
+}
+
+func roundTrip(in, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+type validator struct {
+	root map[string]any
+}
+
+func (vd *validator) fail(path, format string, args ...any) error {
+	return &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+func (vd *validator) validate(s map[string]any, v any, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := vd.resolve(ref)
+		if err != nil {
+			return err
+		}
+		if err := vd.validate(target, v, path); err != nil {
+			return err
+		}
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, v) {
+		return vd.fail(path, "expected %s, got %s", typeNames(t), jsonType(v))
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, v) {
+		return vd.fail(path, "must be %v", c)
+	}
+	if enum, ok := s["enum"].([]any); ok && !contains(enum, v) {
+		return vd.fail(path, "must be one of %v", enum)
+	}
+
+	switch v := v.(type) {
+	case float64:
+		if err := vd.validateNumber(s, v, path); err != nil {
+			return err
+		}
+	case string:
+		if err := vd.validateString(s, v, path); err != nil {
+			return err
+		}
+	case []any:
+		if err := vd.validateArray(s, v, path); err != nil {
+			return err
+		}
+	case map[string]any:
+		if err := vd.validateObject(s, v, path); err != nil {
+			return err
+		}
+	}
+
+	return vd.validateCombinators(s, v, path)
+}
+
+func (vd *validator) resolve(ref string) (map[string]any, error) {
+	if ref == "#" {
+		return vd.root, nil
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("schema: unsupported $ref %q", ref)
+	}
+	defs, _ := vd.root["$defs"].(map[string]any)
+	def, ok := defs[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("schema: unresolved $ref %q", ref)
+	}
+	return def, nil
+}
+
+func (vd *validator) validateNumber(s map[string]any, n float64, path string) error {
+	if m, ok := s["minimum"].(float64); ok && n < m {
+		return vd.fail(path, "must be >= %v", m)
+	}
+	if m, ok := s["maximum"].(float64); ok && n > m {
+		return vd.fail(path, "must be <= %v", m)
+	}
+	if m, ok := s["exclusiveMinimum"].(float64); ok && n <= m {
+		return vd.fail(path, "must be > %v", m)
+	}
+	if m, ok := s["exclusiveMaximum"].(float64); ok && n >= m {
+		return vd.fail(path, "must be < %v", m)
+	}
+	return nil
+}
+
+func (vd *validator) validateString(s map[string]any, str string, path string) error {
+	n := float64(utf8.RuneCountInString(str))
+	if m, ok := s["minLength"].(float64); ok && n < m {
+		return vd.fail(path, "must be at least %v characters", m)
+	}
+	if m, ok := s["maxLength"].(float64); ok && n > m {
+		return vd.fail(path, "must be at most %v characters", m)
+	}
+	if p, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("schema: invalid pattern %q: %w", p, err)
+		}
+		if !re.MatchString(str) {
+			return vd.fail(path, "must match %q", p)
+		}
+	}
+	return nil
+}
+
+func (vd *validator) validateArray(s map[string]any, arr []any, path string) error {
+	n := float64(len(arr))
+	if m, ok := s["minItems"].(float64); ok && n < m {
+		return vd.fail(path, "must have at least %v items", m)
+	}
+	if m, ok := s["maxItems"].(float64); ok && n > m {
+		return vd.fail(path, "must have at most %v items", m)
+	}
+	if items, ok := s["items"].(map[string]any); ok {
+		for i, item := range arr {
+			if err := vd.validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (vd *validator) validateObject(s map[string]any, obj map[string]any, path string) error {
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := obj[name]; !ok {
+				return vd.fail(joinPath(path, name), "is required")
+			}
+		}
+	}
+
+	props, _ := s["properties"].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		value := obj[name]
+		if ps, ok := props[name].(map[string]any); ok {
+			if err := vd.validate(ps, value, joinPath(path, name)); err != nil {
+				return err
+			}
+			continue
+		}
+		switch extra := s["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				return vd.fail(joinPath(path, name), "is not allowed")
+			}
+		case map[string]any:
+			if err := vd.validate(extra, value, joinPath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (vd *validator) validateCombinators(s map[string]any, v any, path string) error {
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			if m, ok := sub.(map[string]any); ok {
+				if err := vd.validate(m, v, path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok && vd.countMatches(anyOf, v, path) == 0 {
+		return vd.fail(path, "does not match any allowed schema")
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok && vd.countMatches(oneOf, v, path) != 1 {
+		return vd.fail(path, "must match exactly one allowed schema")
+	}
+	return nil
+}
+
+func (vd *validator) countMatches(schemas []any, v any, path string) int {
+	n := 0
+	for _, sub := range schemas {
+		if m, ok := sub.(map[string]any); ok && vd.validate(m, v, path) == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func matchesType(t any, v any) bool {
+	switch t := t.(type) {
+	case string:
+		return matchesTypeName(t, v)
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok && matchesTypeName(s, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTypeName(name string, v any) bool {
+	switch name {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return jsonType(v) == name
+	}
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, len(list))
+		for i, n := range list {
+			names[i] = fmt.Sprint(n)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func contains(values []any, v any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, v) {
+			return true
+		}
+	}
+	return false
+}
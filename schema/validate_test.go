@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	s, err := For[person]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	valid := map[string]any{
+		"id":       "p-1",
+		"name":     "Ada",
+		"role":     "admin",
+		"address":  map[string]any{"city": "London", "country": "GB"},
+		"birthday": "1815-12-10T00:00:00Z",
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(map[string]any)
+		wantPath string
+	}{
+		{"valid", func(map[string]any) {}, ""},
+		{"missing required", func(v map[string]any) { delete(v, "name") }, "name"},
+		{"wrong type", func(v map[string]any) { v["age"] = "old" }, "age"},
+		{"fractional integer", func(v map[string]any) { v["age"] = 3.5 }, "age"},
+		{"out of range", func(v map[string]any) { v["age"] = 200 }, "age"},
+		{"enum", func(v map[string]any) { v["role"] = "owner" }, "role"},
+		{"nested", func(v map[string]any) { v["address"] = map[string]any{"city": "Paris", "country": "FRA"} }, "address.country"},
+		{"additional property", func(v map[string]any) { v["nickname"] = "A" }, "nickname"},
+		{"array length", func(v map[string]any) { v["tags"] = []string{"a", "b", "c", "d", "e", "f"} }, "tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := make(map[string]any)
+			for k, val := range valid {
+				v[k] = val
+			}
+			tt.mutate(v)
+
+			err := Validate(s, v)
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected *ValidationError, got %T (%v)", err, err)
+			}
+			if verr.Path != tt.wantPath {
+				t.Errorf("Expected error at %q, got %q (%v)", tt.wantPath, verr.Path, verr)
+			}
+		})
+	}
+}
+
+func TestValidateRecursive(t *testing.T) {
+	s, err := For[tree]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	v := tree{Root: node{Value: 1, Children: []*node{{Value: 2}, {Value: 3, Children: []*node{{Value: 4}}}}}}
+	if err := Validate(s, v); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	bad := map[string]any{"root": map[string]any{"value": 1, "children": []any{map[string]any{"value": "x"}}}}
+	var verr *ValidationError
+	if err := Validate(s, bad); !errors.As(err, &verr) || verr.Path != "root.children[0].value" {
+		t.Errorf("Expected error at root.children[0].value, got %v", err)
+	}
+}
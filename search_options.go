@@ -0,0 +1,106 @@
+// path: parallel/search_options.go
+package parallel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Search processors for ParallelSearchRequest.Processor.
+const (
+	SearchProcessorBase = "base"
+	SearchProcessorPro  = "pro"
+)
+
+// Search modes for ParallelSearchRequest.Mode.
+const (
+	SearchModeOneShot = "one-shot"
+	SearchModeAgentic = "agentic"
+)
+
+// SourcePolicyDateLayout is the layout of SourcePolicy.AfterDate.
+const SourcePolicyDateLayout = time.DateOnly
+
+// Validate checks the request locally: numeric limits, that Processor and
+// Mode are known and not both set, and that the source policy holds valid,
+// non-conflicting domains and a valid date. Search calls it before sending.
+func (r ParallelSearchRequest) Validate() error {
+	switch {
+	case r.MaxResults < 0:
+		return &ValidationError{Field: "max_results", Message: "must not be negative"}
+	case r.MaxCharsPerResult < 0:
+		return &ValidationError{Field: "max_chars_per_result", Message: "must not be negative"}
+	case r.Processor != "" && r.Mode != "":
+		return &ValidationError{Field: "mode", Message: "cannot be combined with processor"}
+	}
+	switch r.Processor {
+	case "", SearchProcessorBase, SearchProcessorPro:
+	default:
+		return &ValidationError{Field: "processor", Message: fmt.Sprintf("unknown processor %q", r.Processor)}
+	}
+	switch r.Mode {
+	case "", SearchModeOneShot, SearchModeAgentic:
+	default:
+		return &ValidationError{Field: "mode", Message: fmt.Sprintf("unknown mode %q", r.Mode)}
+	}
+	if r.SourcePolicy == nil {
+		return nil
+	}
+	if err := r.SourcePolicy.validate(); err != nil {
+		err.Field = joinField("source_policy", err.Field)
+		return err
+	}
+	return nil
+}
+
+func (p *SourcePolicy) validate() *ValidationError {
+	include := make(map[string]bool, len(p.IncludeDomains))
+	for i, d := range p.IncludeDomains {
+		if msg := checkDomain(d); msg != "" {
+			return &ValidationError{Field: fmt.Sprintf("include_domains[%d]", i), Message: msg}
+		}
+		include[strings.ToLower(d)] = true
+	}
+	for i, d := range p.ExcludeDomains {
+		field := fmt.Sprintf("exclude_domains[%d]", i)
+		if msg := checkDomain(d); msg != "" {
+			return &ValidationError{Field: field, Message: msg}
+		}
+		if include[strings.ToLower(d)] {
+			return &ValidationError{Field: field, Message: fmt.Sprintf("%q is also in include_domains", d)}
+		}
+	}
+	if p.AfterDate != "" {
+		if _, err := time.Parse(SourcePolicyDateLayout, p.AfterDate); err != nil {
+			return &ValidationError{Field: "after_date", Message: "must be a date formatted as YYYY-MM-DD"}
+		}
+	}
+	return nil
+}
+
+// checkDomain describes what is wrong with domain, or returns "" if it is a
+// valid host name such as "example.com".
+func checkDomain(domain string) string {
+	if strings.Contains(domain, "://") || strings.ContainsAny(domain, "/?#") {
+		return fmt.Sprintf("%q is not a bare domain", domain)
+	}
+	if len(domain) > 253 {
+		return "domain is longer than 253 characters"
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return fmt.Sprintf("%q is not a fully qualified domain", domain)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Sprintf("%q has an invalid label %q", domain, label)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Sprintf("%q contains invalid character %q", domain, r)
+			}
+		}
+	}
+	return ""
+}
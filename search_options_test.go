@@ -0,0 +1,90 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchSourcePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["processor"] != SearchProcessorPro {
+			t.Errorf("Expected processor to be %q, got %v", SearchProcessorPro, body["processor"])
+		}
+		if _, ok := body["mode"]; ok {
+			t.Errorf("Expected mode to be omitted, got %v", body["mode"])
+		}
+		policy, _ := body["source_policy"].(map[string]any)
+		if policy["after_date"] != "2025-01-01" {
+			t.Errorf("Expected after_date to be 2025-01-01, got %v", policy["after_date"])
+		}
+		if domains, _ := policy["include_domains"].([]any); len(domains) != 2 {
+			t.Errorf("Expected 2 include_domains, got %v", policy["include_domains"])
+		}
+		json.NewEncoder(w).Encode(ParallelSearchResponse{SearchID: "test-search-id"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	_, err := client.Search(context.Background(), ParallelSearchRequest{
+		Objective: "test objective",
+		Processor: SearchProcessorPro,
+		SourcePolicy: &SourcePolicy{
+			IncludeDomains: []string{"example.com", "docs.example.org"},
+			AfterDate:      "2025-01-01",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestSearchRequestValidate(t *testing.T) {
+	tests := map[string]struct {
+		req   ParallelSearchRequest
+		field string
+	}{
+		"negative max results": {ParallelSearchRequest{MaxResults: -1}, "max_results"},
+		"processor and mode":   {ParallelSearchRequest{Processor: SearchProcessorBase, Mode: SearchModeAgentic}, "mode"},
+		"unknown mode":         {ParallelSearchRequest{Mode: "fast"}, "mode"},
+		"url as domain": {
+			ParallelSearchRequest{SourcePolicy: &SourcePolicy{IncludeDomains: []string{"https://example.com"}}},
+			"source_policy.include_domains[0]",
+		},
+		"invalid label": {
+			ParallelSearchRequest{SourcePolicy: &SourcePolicy{ExcludeDomains: []string{"ok.com", "-bad.com"}}},
+			"source_policy.exclude_domains[1]",
+		},
+		"included and excluded": {
+			ParallelSearchRequest{SourcePolicy: &SourcePolicy{IncludeDomains: []string{"example.com"}, ExcludeDomains: []string{"Example.com"}}},
+			"source_policy.exclude_domains[0]",
+		},
+		"invalid date": {
+			ParallelSearchRequest{SourcePolicy: &SourcePolicy{AfterDate: "01/02/2025"}},
+			"source_policy.after_date",
+		},
+	}
+	for name, tt := range tests {
+		err := tt.req.Validate()
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: Expected *ValidationError, got %v", name, err)
+			continue
+		}
+		if verr.Field != tt.field {
+			t.Errorf("%s: Expected field %q, got %q", name, tt.field, verr.Field)
+		}
+		if !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: Expected errors.Is(err, ErrInvalidRequest) to be true", name)
+		}
+	}
+
+	if err := (ParallelSearchRequest{Mode: SearchModeOneShot, SourcePolicy: &SourcePolicy{IncludeDomains: []string{"sub.example.co.uk"}}}).Validate(); err != nil {
+		t.Errorf("Expected valid request, got %v", err)
+	}
+}
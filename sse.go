@@ -0,0 +1,81 @@
+// path: parallel/sse.go
+package parallel
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// sseEvent is one dispatched server-sent event.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// sseReader parses a text/event-stream body.
+type sseReader struct {
+	r      *bufio.Reader
+	lastID string
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event with a non-empty data field, or io.EOF once the
+// stream ends. Comments and events without data are skipped.
+func (s *sseReader) Next() (sseEvent, error) {
+	var (
+		ev   sseEvent
+		data strings.Builder
+		seen bool
+	)
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return sseEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if seen {
+				ev.ID = s.lastID
+				ev.Data = data.String()
+				return ev, nil
+			}
+			ev = sseEvent{}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			ev.Event = value
+		case "data":
+			if seen {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			seen = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				s.lastID = value
+			}
+		}
+
+		if err == io.EOF {
+			if seen {
+				ev.ID = s.lastID
+				ev.Data = data.String()
+				return ev, nil
+			}
+			return sseEvent{}, io.EOF
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// path: parallel/task_cancel.go
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// abandonCancelTimeout bounds the CancelTask call made after the caller's context is done.
+const abandonCancelTimeout = 10 * time.Second
+
+// WithCancelOnAbort makes the client cancel a task run on the server when the
+// context of a call waiting on it (PollUntilComplete, GetTaskResult) is done
+// before the run finishes, so abandoned runs stop incurring cost.
+func WithCancelOnAbort() Option {
+	return func(c *Client) {
+		c.cancelOnAbort = true
+	}
+}
+
+// CancelTask requests cancellation of a task run and returns its updated state.
+// The run moves to "cancelling" and then "cancelled"; runs that already
+// finished are left unchanged.
+func (c *Client) CancelTask(ctx context.Context, runID string) (*ParallelTaskResult, error) {
+	return do[ParallelTaskResult](ctx, c, &Call{
+		Operation:  OpCancelTask,
+		Method:     http.MethodPost,
+		Path:       fmt.Sprintf("/tasks/runs/%s/cancel", runID),
+		idempotent: true,
+	})
+}
+
+// cancelIfAborted cancels runID on the server if cancelOnAbort is set and ctx
+// is done. The request uses a context detached from ctx; its outcome is ignored
+// because the caller is already returning ctx's error.
+func (c *Client) cancelIfAborted(ctx context.Context, runID string, cancelOnAbort bool) {
+	if !cancelOnAbort || ctx.Err() == nil {
+		return
+	}
+	cctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abandonCancelTimeout)
+	defer cancel()
+	c.CancelTask(cctx, runID)
+}
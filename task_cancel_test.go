@@ -0,0 +1,73 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCancelTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1beta/tasks/runs/test-run-id/cancel" {
+			t.Errorf("Expected POST '/v1beta/tasks/runs/test-run-id/cancel', got %s %s", r.Method, r.URL.Path)
+		}
+		if body, _ := io.ReadAll(r.Body); len(body) != 0 || r.Header.Get("Content-Type") != "" {
+			t.Errorf("Expected an empty request body, got %q (%s)", body, r.Header.Get("Content-Type"))
+		}
+		json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id", Status: TaskStatusCancelling})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
+
+	resp, err := client.CancelTask(context.Background(), "test-run-id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Status != TaskStatusCancelling {
+		t.Errorf("Expected Status to be 'cancelling', got %s", resp.Status)
+	}
+}
+
+func TestPollUntilCompleteCancelOnAbort(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectCancels int32
+	}{
+		{"disabled by default", nil, 0},
+		{"enabled", []Option{WithCancelOnAbort()}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cancels atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/tasks/runs/test-run-id/cancel" {
+					cancels.Add(1)
+				}
+				json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id", Status: TaskStatusRunning, IsActive: boolPtr(true)})
+			}))
+			defer server.Close()
+
+			opts := append([]Option{WithAPIKey("test-api-key"), WithBaseURL(server.URL)}, tt.opts...)
+			client := NewClient(opts...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+			defer cancel()
+
+			if _, err := client.PollUntilComplete(ctx, "test-run-id", 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+			}
+			if cancels.Load() != tt.expectCancels {
+				t.Errorf("Expected %d cancel requests, got %d", tt.expectCancels, cancels.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// path: parallel/task_events.go
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// TaskEventsBetaTag is the parallel-beta value required by the event stream endpoints.
+const TaskEventsBetaTag = "events-sse-2025-07-24"
+
+// Task run event types reported in TaskEvent.Type. Progress messages use
+// TaskEventProgressMsg as a prefix, e.g. "task_run.progress_msg.plan".
+const (
+	TaskEventState         = "task_run.state"
+	TaskEventProgressMsg   = "task_run.progress_msg"
+	TaskEventProgressStats = "task_run.progress_stats"
+	TaskEventError         = "error"
+)
+
+// TaskEvent is one event from a task run's event stream.
+type TaskEvent struct {
+	ID        string              `json:"-"` // SSE event ID, used to resume the stream
+	Type      string              `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	Run       *ParallelTaskResult `json:"run,omitempty"`     // status change, set for state events
+	Output    any                 `json:"output,omitempty"`  // final output, set once the run completes
+	Message   string              `json:"message,omitempty"` // progress message text
+	Stats     map[string]any      `json:"stats,omitempty"`   // progress counters
+	Error     any                 `json:"error,omitempty"`   // error details for error events
+}
+
+// isFinal reports whether the event reports a finished run.
+func (e TaskEvent) isFinal() bool {
+	return e.Type == TaskEventState && e.Run != nil && e.Run.finished()
+}
+
+// WithTaskEventStreaming makes PollUntilComplete follow the task run event
+// stream instead of polling, falling back to polling if the endpoint is unavailable.
+func WithTaskEventStreaming() Option {
+	return func(c *Client) {
+		c.taskEvents = true
+	}
+}
+
+// StreamTaskEvents follows the event stream of a task run, yielding status
+// changes, progress messages and the final output. If the connection drops
+// before the run finishes, it reconnects and resumes from the last event ID.
+// Iteration ends after the final state event or the first error.
+func (c *Client) StreamTaskEvents(ctx context.Context, runID string) iter.Seq2[TaskEvent, error] {
+	path := fmt.Sprintf("/tasks/runs/%s/events", runID)
+	return followEvents(ctx, c, OpTaskEvents, path, func(ev sseEvent) (TaskEvent, bool, error) {
+		var event TaskEvent
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			return event, true, &DecodeError{Err: err}
+		}
+		event.ID = ev.ID
+		if event.Type == "" {
+			event.Type = ev.Event
+		}
+		if event.Type == TaskEventError {
+			return event, true, fmt.Errorf("task event stream for %s: %v", runID, event.Error)
+		}
+		return event, event.isFinal(), nil
+	})
+}
+
+// followEvents consumes a resumable SSE endpoint. decode turns each event into
+// an E and reports whether it is the last one; a decode error is yielded
+// alongside the event and ends iteration. Dropped connections are resumed with
+// Last-Event-ID, giving up after the retry policy's MaxAttempts consecutive
+// reconnects that receive no events.
+func followEvents[E any](ctx context.Context, c *Client, op, path string, decode func(sseEvent) (E, bool, error)) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		var lastID string
+		for attempt := 1; ; attempt++ {
+			header := http.Header{
+				"Accept":        {"text/event-stream"},
+				"parallel-beta": {c.betaTag + "," + TaskEventsBetaTag},
+			}
+			if lastID != "" {
+				header.Set("Last-Event-ID", lastID)
+			}
+
+			body, err := do[streamBody](ctx, c, &Call{
+				Operation:  op,
+				Method:     http.MethodGet,
+				Path:       path,
+				Header:     header,
+				Request:    &path,
+				idempotent: true,
+			})
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			events := newSSEReader(body)
+			for {
+				ev, err := events.Next()
+				if err != nil {
+					break
+				}
+				attempt = 0
+				lastID = ev.ID
+
+				event, final, err := decode(ev)
+				if err != nil {
+					yield(event, err)
+					body.Close()
+					return
+				}
+				if !yield(event, nil) || final {
+					body.Close()
+					return
+				}
+			}
+			body.Close()
+
+			if ctx.Err() != nil {
+				yield(zero, ctx.Err())
+				return
+			}
+			if attempt >= c.retry.MaxAttempts {
+				yield(zero, fmt.Errorf("event stream %s: %w", path, io.ErrUnexpectedEOF))
+				return
+			}
+			if err := sleep(ctx, c.retry.backoff(max(attempt, 1))); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// pollViaEvents waits for a run to finish by following its event stream,
+// reporting state changes to update, then fetches the final result.
+func (c *Client) pollViaEvents(ctx context.Context, runID string, update func(*ParallelTaskResult)) (*ParallelTaskResult, error) {
+	for event, err := range c.StreamTaskEvents(ctx, runID) {
+		if err != nil {
+			return nil, err
+		}
+		if event.isFinal() {
+			task, err := c.GetTask(ctx, runID)
+			if err != nil {
+				return nil, err
+			}
+			update(task)
+			return checkFinished(task)
+		}
+		if event.Type == TaskEventState && event.Run != nil {
+			update(event.Run)
+		}
+	}
+	return nil, fmt.Errorf("task event stream for %s ended before the run finished", runID)
+}
+
+// eventsUnavailable reports whether err means the events endpoint cannot be used.
+func eventsUnavailable(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidRequest)
+}
@@ -0,0 +1,94 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStreamTaskEvents(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/tasks/runs/test-run-id/events" {
+			t.Errorf("Expected to request '/v1beta/tasks/runs/test-run-id/events', got %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("parallel-beta"), TaskEventsBetaTag) {
+			t.Errorf("Expected parallel-beta header to contain %s, got %s", TaskEventsBetaTag, r.Header.Get("parallel-beta"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch connections.Add(1) {
+		case 1:
+			fmt.Fprint(w, "id: 1\ndata: {\"type\":\"task_run.state\",\"run\":{\"run_id\":\"test-run-id\",\"status\":\"running\"}}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\":\"task_run.progress_msg.plan\",\"message\":\"Planning\"}\n\n")
+		default:
+			if r.Header.Get("Last-Event-ID") != "2" {
+				t.Errorf("Expected Last-Event-ID to be '2', got %s", r.Header.Get("Last-Event-ID"))
+			}
+			fmt.Fprint(w, "id: 3\ndata: {\"type\":\"task_run.state\",\"run\":{\"run_id\":\"test-run-id\",\"status\":\"completed\"},\"output\":{\"content\":\"done\"}}\n\n")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"), WithRetryPolicy(fastRetries))
+
+	var types []string
+	var final TaskEvent
+	for event, err := range client.StreamTaskEvents(context.Background(), "test-run-id") {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		types = append(types, event.Type)
+		final = event
+	}
+
+	expected := "task_run.state|task_run.progress_msg.plan|task_run.state"
+	if strings.Join(types, "|") != expected {
+		t.Errorf("Expected event types %s, got %s", expected, strings.Join(types, "|"))
+	}
+	if final.Run.Status != "completed" || final.Output == nil {
+		t.Errorf("Expected final event to carry completed status and output, got %+v", final)
+	}
+	if connections.Load() != 2 {
+		t.Errorf("Expected 2 connections, got %d", connections.Load())
+	}
+}
+
+func TestPollUntilCompleteWithEvents(t *testing.T) {
+	tests := []struct {
+		name         string
+		eventsStatus int
+	}{
+		{"streams events", http.StatusOK},
+		{"falls back to polling", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/events") {
+					w.WriteHeader(tt.eventsStatus)
+					fmt.Fprint(w, "data: {\"type\":\"task_run.state\",\"run\":{\"status\":\"completed\"}}\n\n")
+					return
+				}
+				json.NewEncoder(w).Encode(ParallelTaskResult{RunID: "test-run-id", Status: "completed"})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithTaskEventStreaming())
+
+			resp, err := client.PollUntilComplete(context.Background(), "test-run-id", 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.Status != "completed" {
+				t.Errorf("Expected final status to be 'completed', got %s", resp.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// path: parallel/task_groups.go
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrRepeatedCursor is returned by TaskGroupRuns when the server hands back a
+// page cursor it already returned, which would otherwise loop forever.
+var ErrRepeatedCursor = errors.New("parallel: server repeated a page cursor")
+
+// Task group event types reported in TaskGroupEvent.Type.
+const (
+	TaskGroupEventStatus   = "task_group_status"
+	TaskGroupEventRunState = TaskEventState
+)
+
+// TaskGroupEvent is one event from a task group's event stream.
+type TaskGroupEvent struct {
+	ID        string                   `json:"-"` // SSE event ID, used to resume the stream
+	Type      string                   `json:"type"`
+	Timestamp time.Time                `json:"timestamp"`
+	Status    *ParallelTaskGroupStatus `json:"status,omitempty"` // set for group status events
+	Run       *ParallelTaskResult      `json:"run,omitempty"`    // set when a run changes state
+	Output    any                      `json:"output,omitempty"` // output of a finished run
+	Error     any                      `json:"error,omitempty"`  // error details for error events
+}
+
+// ListTaskGroupRunsOptions controls pagination for ListTaskGroupRuns.
+type ListTaskGroupRunsOptions struct {
+	Limit  int    // page size; 0 uses the server default
+	Cursor string // NextCursor from the previous page
+}
+
+// CreateTaskGroup creates an empty task group.
+func (c *Client) CreateTaskGroup(ctx context.Context, req ParallelTaskGroupRequest) (*ParallelTaskGroup, error) {
+	return do[ParallelTaskGroup](ctx, c, &Call{
+		Operation: OpCreateTaskGroup,
+		Method:    http.MethodPost,
+		Path:      "/tasks/groups",
+		Request:   &req,
+	})
+}
+
+// GetTaskGroup retrieves a task group and the aggregate status of its runs.
+func (c *Client) GetTaskGroup(ctx context.Context, groupID string) (*ParallelTaskGroup, error) {
+	return do[ParallelTaskGroup](ctx, c, &Call{
+		Operation:  OpGetTaskGroup,
+		Method:     http.MethodGet,
+		Path:       fmt.Sprintf("/tasks/groups/%s", groupID),
+		Request:    &groupID,
+		idempotent: true,
+	})
+}
+
+// AddTaskGroupRuns starts a batch of runs inside a task group.
+// Each input is validated locally first; see ParallelTaskRequest.Validate.
+func (c *Client) AddTaskGroupRuns(ctx context.Context, groupID string, req ParallelTaskGroupRunsRequest) (*ParallelTaskGroupRunsResponse, error) {
+	for i, in := range req.Inputs {
+		if err := in.Validate(); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				verr.Field = fmt.Sprintf("inputs[%d].%s", i, verr.Field)
+			}
+			return nil, err
+		}
+	}
+	return do[ParallelTaskGroupRunsResponse](ctx, c, &Call{
+		Operation: OpAddTaskGroupRuns,
+		Method:    http.MethodPost,
+		Path:      fmt.Sprintf("/tasks/groups/%s/runs", groupID),
+		Request:   &req,
+	})
+}
+
+// ListTaskGroupRuns returns one page of the runs in a task group.
+func (c *Client) ListTaskGroupRuns(ctx context.Context, groupID string, opts ListTaskGroupRunsOptions) (*ParallelTaskGroupRunsPage, error) {
+	query := url.Values{}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	path := fmt.Sprintf("/tasks/groups/%s/runs", groupID)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return do[ParallelTaskGroupRunsPage](ctx, c, &Call{
+		Operation:  OpListTaskGroupRuns,
+		Method:     http.MethodGet,
+		Path:       path,
+		Request:    &opts,
+		idempotent: true,
+	})
+}
+
+// TaskGroupRuns iterates over every run in a task group, fetching pages of
+// pageSize runs as needed. Iteration stops after the first error, including
+// ErrRepeatedCursor if the server returns a cursor it already returned.
+func (c *Client) TaskGroupRuns(ctx context.Context, groupID string, pageSize int) iter.Seq2[ParallelTaskResult, error] {
+	return func(yield func(ParallelTaskResult, error) bool) {
+		opts := ListTaskGroupRunsOptions{Limit: pageSize}
+		seen := make(map[string]bool)
+		for {
+			page, err := c.ListTaskGroupRuns(ctx, groupID, opts)
+			if err != nil {
+				yield(ParallelTaskResult{}, err)
+				return
+			}
+			for _, run := range page.Runs {
+				if !yield(run, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			if seen[page.NextCursor] {
+				yield(ParallelTaskResult{}, fmt.Errorf("task group %s: %w %q", groupID, ErrRepeatedCursor, page.NextCursor))
+				return
+			}
+			seen[page.NextCursor] = true
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
+
+// StreamTaskGroupEvents follows a task group's event stream, yielding each
+// run as it changes state (including its output once finished) and the
+// group's aggregate status. Iteration ends once the group is no longer
+// active, or after the first error.
+func (c *Client) StreamTaskGroupEvents(ctx context.Context, groupID string) iter.Seq2[TaskGroupEvent, error] {
+	path := fmt.Sprintf("/tasks/groups/%s/events", groupID)
+	return followEvents(ctx, c, OpTaskGroupEvents, path, func(ev sseEvent) (TaskGroupEvent, bool, error) {
+		var event TaskGroupEvent
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			return event, true, &DecodeError{Err: err}
+		}
+		event.ID = ev.ID
+		if event.Type == "" {
+			event.Type = ev.Event
+		}
+		if event.Type == TaskEventError {
+			return event, true, fmt.Errorf("task group event stream for %s: %v", groupID, event.Error)
+		}
+		return event, event.Type == TaskGroupEventStatus && event.Status != nil && !event.Status.IsActive, nil
+	})
+}
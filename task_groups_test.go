@@ -0,0 +1,144 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTaskGroups(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tasks/groups", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ParallelTaskGroup{TaskGroupID: "tgrp_1", Status: ParallelTaskGroupStatus{IsActive: true}})
+	})
+	mux.HandleFunc("POST /tasks/groups/tgrp_1/runs", func(w http.ResponseWriter, r *http.Request) {
+		var req ParallelTaskGroupRunsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Inputs) != 3 {
+			t.Errorf("Expected 3 inputs, got %d", len(req.Inputs))
+		}
+		json.NewEncoder(w).Encode(ParallelTaskGroupRunsResponse{RunIDs: []string{"run_1", "run_2", "run_3"}})
+	})
+	mux.HandleFunc("GET /tasks/groups/tgrp_1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ParallelTaskGroup{
+			TaskGroupID: "tgrp_1",
+			Status:      ParallelTaskGroupStatus{NumTaskRuns: 3, TaskRunStatusCounts: map[string]int{"completed": 3}},
+		})
+	})
+	mux.HandleFunc("GET /tasks/groups/tgrp_1/runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit to be 2, got %s", r.URL.Query().Get("limit"))
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(ParallelTaskGroupRunsPage{
+				Runs:       []ParallelTaskResult{{RunID: "run_1"}, {RunID: "run_2"}},
+				NextCursor: "page-2",
+			})
+		case "page-2":
+			json.NewEncoder(w).Encode(ParallelTaskGroupRunsPage{Runs: []ParallelTaskResult{{RunID: "run_3"}}})
+		}
+	})
+	mux.HandleFunc("GET /tasks/groups/tgrp_1/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "id: 1\ndata: {\"type\":\"task_run.state\",\"run\":{\"run_id\":\"run_1\",\"status\":\"completed\"},\"output\":{\"content\":\"a\"}}\n\n")
+		fmt.Fprint(w, "id: 2\ndata: {\"type\":\"task_group_status\",\"status\":{\"num_task_runs\":3,\"is_active\":false}}\n\n")
+		fmt.Fprint(w, "id: 3\ndata: {\"type\":\"task_run.state\",\"run\":{\"run_id\":\"never-read\"}}\n\n")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	group, err := client.CreateTaskGroup(ctx, ParallelTaskGroupRequest{Metadata: map[string]any{"job": "nightly"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if group.TaskGroupID != "tgrp_1" {
+		t.Errorf("Expected TaskGroupID to be 'tgrp_1', got %s", group.TaskGroupID)
+	}
+
+	added, err := client.AddTaskGroupRuns(ctx, "tgrp_1", ParallelTaskGroupRunsRequest{
+		Inputs: []ParallelTaskRequest{{Input: "a"}, {Input: "b"}, {Input: "c"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(added.RunIDs) != 3 {
+		t.Errorf("Expected 3 run IDs, got %d", len(added.RunIDs))
+	}
+
+	group, err = client.GetTaskGroup(ctx, "tgrp_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if group.Status.TaskRunStatusCounts["completed"] != 3 {
+		t.Errorf("Expected 3 completed runs, got %v", group.Status.TaskRunStatusCounts)
+	}
+
+	var runIDs []string
+	for run, err := range client.TaskGroupRuns(ctx, "tgrp_1", 2) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		runIDs = append(runIDs, run.RunID)
+	}
+	if fmt.Sprint(runIDs) != "[run_1 run_2 run_3]" {
+		t.Errorf("Expected runs [run_1 run_2 run_3], got %v", runIDs)
+	}
+
+	var events []string
+	for event, err := range client.StreamTaskGroupEvents(ctx, "tgrp_1") {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		events = append(events, event.Type)
+	}
+	if fmt.Sprint(events) != "[task_run.state task_group_status]" {
+		t.Errorf("Expected events [task_run.state task_group_status], got %v", events)
+	}
+}
+
+func TestTaskGroupRunsRepeatedCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     map[string]string // cursor -> next cursor
+		wantRuns int
+	}{
+		{"immediate", map[string]string{"": "page_2", "page_2": "page_2"}, 2},
+		{"cycle", map[string]string{"": "page_a", "page_a": "page_b", "page_b": "page_a"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(ParallelTaskGroupRunsPage{Runs: []ParallelTaskResult{{RunID: "run_1"}}, NextCursor: tt.next[r.URL.Query().Get("cursor")]})
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+			runs := 0
+			var lastErr error
+			for _, err := range client.TaskGroupRuns(context.Background(), "tgrp_1", 1) {
+				if err != nil {
+					lastErr = err
+					break
+				}
+				runs++
+			}
+			if !errors.Is(lastErr, ErrRepeatedCursor) {
+				t.Errorf("Expected ErrRepeatedCursor, got %v", lastErr)
+			}
+			var decodeErr *DecodeError
+			if errors.As(lastErr, &decodeErr) {
+				t.Errorf("Expected a repeated cursor not to be reported as *DecodeError")
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("Expected %d runs before stopping, got %d", tt.wantRuns, runs)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// path: parallel/task_result.go
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// DefaultResultTimeout is the server-side wait used by GetTaskResult when timeout is zero.
+const DefaultResultTimeout = 60 * time.Second
+
+// resultTimeoutSlack is added to the server-side wait to bound each long-poll request.
+const resultTimeoutSlack = 10 * time.Second
+
+// GetTaskResult blocks until a task run finishes and returns its result.
+// Each request asks the server to wait up to timeout for the run; while the
+// run is still in progress, GetTaskResult issues the next long-poll request
+// until ctx is done. With WithCancelOnAbort the run is cancelled if ctx is done first.
+func (c *Client) GetTaskResult(ctx context.Context, runID string, timeout time.Duration) (*ParallelTaskRunResult, error) {
+	res, err := c.getTaskResult(ctx, runID, timeout)
+	if err != nil {
+		c.cancelIfAborted(ctx, runID, c.cancelOnAbort)
+	}
+	return res, err
+}
+
+func (c *Client) getTaskResult(ctx context.Context, runID string, timeout time.Duration) (*ParallelTaskRunResult, error) {
+	if timeout <= 0 {
+		timeout = DefaultResultTimeout
+	}
+
+	for {
+		wait := timeout
+		if deadline, ok := ctx.Deadline(); ok {
+			wait = min(wait, time.Until(deadline))
+		}
+		if wait <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		secs := int(math.Ceil(wait.Seconds()))
+
+		start := time.Now()
+		attemptCtx, cancel := context.WithTimeout(ctx, time.Duration(secs)*time.Second+resultTimeoutSlack)
+		res, err := do[ParallelTaskRunResult](attemptCtx, c, &Call{
+			Operation:  OpGetTaskResult,
+			Method:     http.MethodGet,
+			Path:       fmt.Sprintf("/tasks/runs/%s/result?timeout=%d", runID, secs),
+			Request:    &runID,
+			idempotent: true,
+			longPoll:   true,
+		})
+		cancel()
+
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !stillRunning(err) {
+			return nil, err
+		}
+		// A server that gives up well before the requested wait should not be hammered.
+		if time.Since(start) < wait/2 {
+			if err := sleep(ctx, c.retry.backoff(1)); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// GetTaskResultTyped is GetTaskResult with the output content decoded into T.
+func GetTaskResultTyped[T any](ctx context.Context, c *Client, runID string, timeout time.Duration) (*TaskRun[T], error) {
+	res, err := c.GetTaskResult(ctx, runID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &TaskRun[T]{
+		RunID:       res.Run.RunID,
+		Status:      res.Run.Status,
+		Processor:   res.Run.Processor,
+		TaskGroupID: res.Run.TaskGroupID,
+		CreatedAt:   res.Run.CreatedAt,
+		CompletedAt: completedAt(&res.Run),
+		Basis:       res.Output.Basis,
+		Warnings:    res.Output.Warnings,
+		Error:       res.Run.Error,
+	}
+	if err := res.Output.Decode(&run.Content); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Decode unmarshals the output content into v.
+func (o ParallelTaskOutput) Decode(v any) error {
+	if len(o.Content) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(o.Content, v); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// stillRunning reports whether a result request timed out server-side
+// because the run has not finished yet.
+func stillRunning(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestTimeout
+}
@@ -0,0 +1,79 @@
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetTaskResult(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if r.URL.Path != "/v1beta/tasks/runs/test-run-id/result" {
+			t.Errorf("Expected to request '/v1beta/tasks/runs/test-run-id/result', got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("timeout") != "5" {
+			t.Errorf("Expected timeout to be 5, got %s", r.URL.Query().Get("timeout"))
+		}
+		if callCount < 3 {
+			w.WriteHeader(http.StatusRequestTimeout)
+			return
+		}
+		fmt.Fprint(w, `{"run":{"run_id":"test-run-id","status":"completed","modified_at":"2025-10-10T12:00:00Z"},
+			"output":{"type":"json","content":{"name":"Acme","employees":42},
+			"basis":[{"field":"name","confidence":"high"}],
+			"warnings":[{"type":"spec_validation_warning","message":"extra field ignored"}]}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL+"/v1beta"))
+
+	res, err := client.GetTaskResult(context.Background(), "test-run-id", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if callCount != 3 {
+		t.Errorf("Expected 3 long-poll requests, got %d", callCount)
+	}
+	if res.Run.Status != "completed" {
+		t.Errorf("Expected Status to be 'completed', got %s", res.Run.Status)
+	}
+	if len(res.Output.Basis) != 1 || res.Output.Basis[0].Confidence != "high" {
+		t.Errorf("Expected one high-confidence basis, got %+v", res.Output.Basis)
+	}
+	if len(res.Output.Warnings) != 1 || res.Output.Warnings[0].Message != "extra field ignored" {
+		t.Errorf("Expected one warning, got %+v", res.Output.Warnings)
+	}
+
+	callCount = 2
+	run, err := GetTaskResultTyped[companyReport](context.Background(), client, "test-run-id", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.Content.Name != "Acme" || run.Content.Employees != 42 {
+		t.Errorf("Expected content {Acme 42}, got %+v", run.Content)
+	}
+	if want := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC); !run.CompletedAt.Equal(want) {
+		t.Errorf("Expected CompletedAt %s, got %s", want, run.CompletedAt)
+	}
+}
+
+func TestGetTaskResultDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestTimeout)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetTaskResult(ctx, "test-run-id", time.Minute); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
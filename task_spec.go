@@ -0,0 +1,53 @@
+// path: parallel/task_spec.go
+package parallel
+
+import (
+	"errors"
+
+	"github.com/Raezil/go-parallel/schema"
+)
+
+// Task schema types reported in ParallelTaskSchema.Type.
+const (
+	TaskSchemaJSON = "json"
+	TaskSchemaText = "text"
+	TaskSchemaAuto = "auto"
+)
+
+// JSONTaskSchema returns a task schema backed by a JSON schema.
+func JSONTaskSchema(s map[string]any) *ParallelTaskSchema {
+	return &ParallelTaskSchema{Type: TaskSchemaJSON, JSONSchema: s}
+}
+
+// TextTaskSchema returns a task schema described in prose.
+func TextTaskSchema(description string) *ParallelTaskSchema {
+	return &ParallelTaskSchema{Type: TaskSchemaText, Description: description}
+}
+
+// TaskSchemaFor returns a JSON task schema generated from the Go type T.
+func TaskSchemaFor[T any]() (*ParallelTaskSchema, error) {
+	s, err := schema.For[T]()
+	if err != nil {
+		return nil, err
+	}
+	return JSONTaskSchema(s), nil
+}
+
+// Validate checks the request locally. If the task spec has a JSON input
+// schema, Input must conform to it.
+func (r ParallelTaskRequest) Validate() error {
+	if r.TaskSpec == nil || r.TaskSpec.InputSchema == nil {
+		return nil
+	}
+	in := r.TaskSpec.InputSchema
+	if in.Type != TaskSchemaJSON || in.JSONSchema == nil {
+		return nil
+	}
+
+	err := schema.Validate(in.JSONSchema, r.Input)
+	var verr *schema.ValidationError
+	if errors.As(err, &verr) {
+		return &ValidationError{Field: joinField("input", verr.Path), Message: verr.Message}
+	}
+	return err
+}
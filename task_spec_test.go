@@ -0,0 +1,94 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type enrichmentRow struct {
+	Company string `json:"company" min:"1"`
+	Country string `json:"country,omitempty" enum:"US,GB,DE"`
+}
+
+func TestRunTaskWithSpec(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		input, _ := body["input"].(map[string]any)
+		if input["company"] != "Acme" {
+			t.Errorf("Expected structured input with company 'Acme', got %v", body["input"])
+		}
+		spec, _ := body["task_spec"].(map[string]any)
+		output, _ := spec["output_schema"].(map[string]any)
+		if output["type"] != "text" || output["description"] != "A one-paragraph summary" {
+			t.Errorf("Expected text output schema, got %v", spec["output_schema"])
+		}
+		json.NewEncoder(w).Encode(ParallelTaskResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	inputSchema, err := TaskSchemaFor[enrichmentRow]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	req := ParallelTaskRequest{
+		Input:     enrichmentRow{Company: "Acme", Country: "US"},
+		Processor: "base",
+		TaskSpec: &ParallelTaskSpec{
+			InputSchema:  inputSchema,
+			OutputSchema: TextTaskSchema("A one-paragraph summary"),
+		},
+		Metadata: map[string]any{"row": 1},
+	}
+
+	if _, err := client.RunTask(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req.Input = map[string]any{"company": "Acme", "country": "FR"}
+	_, err = client.RunTask(context.Background(), req)
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected *ValidationError, got %T (%v)", err, err)
+	}
+	if verr.Field != "input.country" {
+		t.Errorf("Expected Field to be 'input.country', got %s", verr.Field)
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Expected errors.Is(err, ErrInvalidRequest) to be true")
+	}
+	if callCount != 1 {
+		t.Errorf("Expected invalid input not to be sent, got %d calls", callCount)
+	}
+}
+
+func TestRunTaskZeroValueInput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ParallelTaskResponse{})
+	}))
+	defer server.Close()
+
+	type row struct {
+		Company string   `json:"company"`
+		Aliases []string `json:"aliases"`
+	}
+	inputSchema, err := TaskSchemaFor[row]()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	req := ParallelTaskRequest{Input: row{Company: "Acme"}, TaskSpec: &ParallelTaskSpec{InputSchema: inputSchema}}
+	if _, err := client.RunTask(context.Background(), req); err != nil {
+		t.Errorf("Expected a nil slice field to pass validation, got %v", err)
+	}
+}
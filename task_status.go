@@ -0,0 +1,105 @@
+// path: parallel/task_status.go
+package parallel
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TaskStatus is the lifecycle state of a task run.
+type TaskStatus string
+
+// Task run statuses.
+const (
+	TaskStatusQueued         TaskStatus = "queued"
+	TaskStatusActionRequired TaskStatus = "action_required"
+	TaskStatusRunning        TaskStatus = "running"
+	TaskStatusCancelling     TaskStatus = "cancelling"
+	TaskStatusCompleted      TaskStatus = "completed"
+	TaskStatusFailed         TaskStatus = "failed"
+	TaskStatusErrored        TaskStatus = "errored"
+	TaskStatusCancelled      TaskStatus = "cancelled"
+)
+
+// IsTerminal reports whether the run has stopped and its status will not change again.
+func (s TaskStatus) IsTerminal() bool {
+	switch s {
+	case TaskStatusCompleted, TaskStatusFailed, TaskStatusErrored, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsSuccess reports whether the run completed successfully.
+func (s TaskStatus) IsSuccess() bool {
+	return s == TaskStatusCompleted
+}
+
+// isKnown reports whether s is one of the statuses defined above.
+func (s TaskStatus) isKnown() bool {
+	switch s {
+	case TaskStatusQueued, TaskStatusActionRequired, TaskStatusRunning, TaskStatusCancelling:
+		return true
+	}
+	return s.IsTerminal()
+}
+
+// finished reports whether the run has stopped. Statuses this client does
+// not know about are treated as finished only once the server explicitly
+// marks the run inactive.
+func (t *ParallelTaskResult) finished() bool {
+	return t.Status.IsTerminal() || (!t.Status.isKnown() && t.IsActive != nil && !*t.IsActive)
+}
+
+// ParallelTaskError holds the error details of an unsuccessful task run.
+type ParallelTaskError struct {
+	RefID   string `json:"ref_id,omitempty"`
+	Message string `json:"message"`
+	Detail  any    `json:"detail,omitempty"`
+}
+
+func (e *ParallelTaskError) Error() string {
+	return e.Message
+}
+
+// UnmarshalJSON accepts either an error object or a bare message string.
+func (e *ParallelTaskError) UnmarshalJSON(b []byte) error {
+	var msg string
+	if json.Unmarshal(b, &msg) == nil {
+		*e = ParallelTaskError{Message: msg}
+		return nil
+	}
+	type plain ParallelTaskError
+	return json.Unmarshal(b, (*plain)(e))
+}
+
+// TaskFailedError is returned by PollUntilComplete when a run finishes
+// without completing successfully.
+type TaskFailedError struct {
+	RunID  string
+	Status TaskStatus
+	Err    *ParallelTaskError  // error details reported by the run, if any
+	Result *ParallelTaskResult // the final state of the run
+}
+
+func (e *TaskFailedError) Error() string {
+	if e.Err != nil && e.Err.Message != "" {
+		return fmt.Sprintf("task run %s %s: %s", e.RunID, e.Status, e.Err.Message)
+	}
+	return fmt.Sprintf("task run %s %s", e.RunID, e.Status)
+}
+
+func (e *TaskFailedError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// checkFinished converts an unsuccessfully finished run into a TaskFailedError.
+func checkFinished(task *ParallelTaskResult) (*ParallelTaskResult, error) {
+	if task.Status.IsSuccess() {
+		return task, nil
+	}
+	return nil, &TaskFailedError{RunID: task.RunID, Status: task.Status, Err: task.Error, Result: task}
+}
@@ -0,0 +1,124 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTaskStatus(t *testing.T) {
+	tests := []struct {
+		status   TaskStatus
+		terminal bool
+		success  bool
+	}{
+		{TaskStatusQueued, false, false},
+		{TaskStatusRunning, false, false},
+		{TaskStatusCancelling, false, false},
+		{TaskStatusCompleted, true, true},
+		{TaskStatusFailed, true, false},
+		{TaskStatusErrored, true, false},
+		{TaskStatusCancelled, true, false},
+	}
+	for _, tt := range tests {
+		if tt.status.IsTerminal() != tt.terminal {
+			t.Errorf("Expected %s IsTerminal to be %v", tt.status, tt.terminal)
+		}
+		if tt.status.IsSuccess() != tt.success {
+			t.Errorf("Expected %s IsSuccess to be %v", tt.status, tt.success)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestTaskFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   ParallelTaskResult
+		finished bool
+	}{
+		{"terminal", ParallelTaskResult{Status: TaskStatusCompleted}, true},
+		{"running", ParallelTaskResult{Status: TaskStatusRunning, IsActive: boolPtr(false)}, false},
+		{"unknown inactive", ParallelTaskResult{Status: "expired", IsActive: boolPtr(false)}, true},
+		{"unknown active", ParallelTaskResult{Status: "paused", IsActive: boolPtr(true)}, false},
+		{"unknown without is_active", ParallelTaskResult{Status: "paused"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.result.finished(); got != tt.finished {
+			t.Errorf("%s: Expected finished to be %v, got %v", tt.name, tt.finished, got)
+		}
+	}
+}
+
+func TestPollUnknownStatusWithoutIsActive(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			fmt.Fprint(w, `{"run_id":"test-run-id","status":"paused"}`)
+			return
+		}
+		fmt.Fprint(w, `{"run_id":"test-run-id","status":"completed"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	res, err := client.PollUntilComplete(context.Background(), "test-run-id", time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if res.Status != TaskStatusCompleted || calls != 3 {
+		t.Errorf("Expected to keep polling through an unknown status, got %s after %d calls", res.Status, calls)
+	}
+}
+
+func TestPollUntilCompleteUnsuccessful(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  TaskStatus
+		wantMessage string
+	}{
+		{"failed", `{"run_id":"test-run-id","status":"failed","error":{"ref_id":"ref-1","message":"processor crashed"}}`, TaskStatusFailed, "processor crashed"},
+		{"cancelled", `{"run_id":"test-run-id","status":"cancelled"}`, TaskStatusCancelled, ""},
+		{"unknown inactive status", `{"run_id":"test-run-id","status":"expired","is_active":false,"error":"run expired"}`, "expired", "run expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			res, err := client.PollUntilComplete(ctx, "test-run-id", time.Millisecond)
+			if res != nil {
+				t.Errorf("Expected no result, got %+v", res)
+			}
+
+			var failed *TaskFailedError
+			if !errors.As(err, &failed) {
+				t.Fatalf("Expected *TaskFailedError, got %T (%v)", err, err)
+			}
+			if failed.Status != tt.wantStatus {
+				t.Errorf("Expected Status to be %s, got %s", tt.wantStatus, failed.Status)
+			}
+			if tt.wantMessage != "" && (failed.Err == nil || failed.Err.Message != tt.wantMessage) {
+				t.Errorf("Expected error message %q, got %+v", tt.wantMessage, failed.Err)
+			}
+			if failed.Result == nil || failed.Result.RunID != "test-run-id" {
+				t.Errorf("Expected the final result to be attached, got %+v", failed.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// path: parallel/task_typed.go
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// TaskRun is a task run whose output content is decoded into a caller-supplied type T.
+type TaskRun[T any] struct {
+	RunID       string             `json:"run_id"`
+	Status      TaskStatus         `json:"status"`
+	Processor   string             `json:"processor"`
+	TaskGroupID string             `json:"taskgroup_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt time.Time          `json:"completed_at"` // zero until the run is terminal; see DecodeTaskRun
+	Content     T                  `json:"content"`
+	Basis       []ParallelBasis    `json:"basis"` // reasoning and citations per output field
+	Warnings    any                `json:"warnings"`
+	Error       *ParallelTaskError `json:"error"`
+}
+
+// taskRunResponse is the /tasks/runs response with its content decoded into T.
+type taskRunResponse[T any] struct {
+	Output TaskRun[T] `json:"output"`
+}
+
+// taskOutput is the output object of a task run result.
+type taskOutput[T any] struct {
+	Content T               `json:"content"`
+	Basis   []ParallelBasis `json:"basis"`
+}
+
+// RunTaskTyped launches a task and decodes its output content into T.
+// Use TaskSchemaFor[T] as the output schema to have Parallel produce that shape.
+func RunTaskTyped[T any](ctx context.Context, c *Client, req ParallelTaskRequest) (*TaskRun[T], error) {
+	call, err := c.runTaskCall(&req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := do[taskRunResponse[T]](ctx, c, call)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Output, nil
+}
+
+// GetTaskTyped retrieves a task run and decodes its output content into T.
+func GetTaskTyped[T any](ctx context.Context, c *Client, runID string) (*TaskRun[T], error) {
+	task, err := c.GetTask(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTaskRun[T](task)
+}
+
+// DecodeTaskRun converts a ParallelTaskResult, e.g. from PollUntilComplete,
+// into a TaskRun whose output content is decoded into T.
+//
+// The run status does not carry a completion time, so CompletedAt is
+// approximated by the run's last modification once its status is terminal,
+// and left zero otherwise.
+func DecodeTaskRun[T any](task *ParallelTaskResult) (*TaskRun[T], error) {
+	run := &TaskRun[T]{
+		RunID:       task.RunID,
+		Status:      task.Status,
+		Processor:   task.Processor,
+		TaskGroupID: task.TaskGroupID,
+		CreatedAt:   task.CreatedAt,
+		CompletedAt: completedAt(task),
+		Warnings:    task.Warnings,
+		Error:       task.Error,
+	}
+	if task.Output == nil {
+		return run, nil
+	}
+
+	raw, err := json.Marshal(task.Output)
+	if err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+	var out taskOutput[T]
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, &DecodeError{Err: err}
+	}
+	run.Content, run.Basis = out.Content, out.Basis
+	return run, nil
+}
+
+// completedAt approximates a run's completion time by its last modification,
+// which is final once the run is terminal.
+func completedAt(task *ParallelTaskResult) time.Time {
+	if !task.Status.IsTerminal() {
+		return time.Time{}
+	}
+	return task.ModifiedAt
+}
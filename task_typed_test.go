@@ -0,0 +1,106 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type companyReport struct {
+	Name      string `json:"name"`
+	Employees int    `json:"employees"`
+}
+
+func TestRunTaskTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"output":{"run_id":"test-run-id","status":"completed",
+			"content":{"name":"Acme","employees":42},
+			"basis":[{"field":"employees","citations":[{"url":"https://acme.example"}],"confidence":"high"}]}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	run, err := RunTaskTyped[companyReport](context.Background(), client, ParallelTaskRequest{Input: "Acme"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.RunID != "test-run-id" {
+		t.Errorf("Expected RunID to be 'test-run-id', got %s", run.RunID)
+	}
+	if run.Content.Name != "Acme" || run.Content.Employees != 42 {
+		t.Errorf("Expected content {Acme 42}, got %+v", run.Content)
+	}
+	if len(run.Basis) != 1 || run.Basis[0].Citations[0].URL != "https://acme.example" {
+		t.Errorf("Expected basis citation https://acme.example, got %+v", run.Basis)
+	}
+}
+
+func TestGetTaskTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ParallelTaskResult{
+			RunID:  "test-run-id",
+			Status: "completed",
+			Output: map[string]any{
+				"type":    "json",
+				"content": map[string]any{"name": "Globex", "employees": 7},
+				"basis":   []map[string]any{{"field": "name", "reasoning": "from homepage"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	run, err := GetTaskTyped[companyReport](context.Background(), client, "test-run-id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if run.Status != "completed" {
+		t.Errorf("Expected Status to be 'completed', got %s", run.Status)
+	}
+	if run.Content.Name != "Globex" || run.Content.Employees != 7 {
+		t.Errorf("Expected content {Globex 7}, got %+v", run.Content)
+	}
+	if len(run.Basis) != 1 || run.Basis[0].Reasoning != "from homepage" {
+		t.Errorf("Expected basis reasoning 'from homepage', got %+v", run.Basis)
+	}
+}
+
+func TestDecodeTaskRunCompletedAt(t *testing.T) {
+	modified := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	running, err := DecodeTaskRun[companyReport](&ParallelTaskResult{Status: TaskStatusRunning, ModifiedAt: modified})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !running.CompletedAt.IsZero() {
+		t.Errorf("Expected no CompletedAt for a running task, got %s", running.CompletedAt)
+	}
+
+	done, err := DecodeTaskRun[companyReport](&ParallelTaskResult{Status: TaskStatusCompleted, ModifiedAt: modified})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !done.CompletedAt.Equal(modified) {
+		t.Errorf("Expected CompletedAt %s, got %s", modified, done.CompletedAt)
+	}
+}
+
+func TestResponseFormatFor(t *testing.T) {
+	format, err := ResponseFormatFor[companyReport]("company_report")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format.Type != "json_schema" || format.JSONSchema.Name != "company_report" {
+		t.Errorf("Expected json_schema format named company_report, got %+v", format)
+	}
+	props, _ := format.JSONSchema.Schema["properties"].(map[string]any)
+	if _, ok := props["employees"]; !ok {
+		t.Errorf("Expected schema to describe the employees property, got %v", format.JSONSchema.Schema)
+	}
+}
@@ -0,0 +1,274 @@
+// path: parallel/task_watcher.go
+package parallel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Defaults used by NewTaskWatcher for zero WatcherOptions fields.
+const (
+	DefaultWatcherRequestsPerSecond = 10
+	DefaultWatcherMinInterval       = 2 * time.Second
+	DefaultWatcherConcurrency       = 4
+)
+
+// WatcherOptions configures a TaskWatcher.
+type WatcherOptions struct {
+	RequestsPerSecond float64           // global cap on status requests across all runs
+	MinInterval       time.Duration     // minimum time between two checks of the same run
+	Concurrency       int               // maximum number of status requests in flight
+	OnResult          func(WatchResult) // receives finished runs; if nil, they are sent on Results
+}
+
+// WatchResult is the outcome of a watched run.
+type WatchResult struct {
+	RunID  string
+	Result *ParallelTaskResult // final state of the run; nil if it could not be retrieved
+	Err    error               // *TaskFailedError for unsuccessful runs, or a non-retryable request error
+}
+
+// TaskWatcher watches many task runs from one shared scheduler. Runs are
+// checked round-robin under a global request-rate cap, and each run is
+// delivered once it finishes. Runs can be added and removed at any time.
+type TaskWatcher struct {
+	c       *Client
+	opts    WatcherOptions
+	results chan WatchResult
+	wake    chan struct{}
+
+	mu    sync.Mutex
+	queue []string // round-robin order; checked runs move to the back
+	runs  map[string]*watchedRun
+}
+
+type watchedRun struct {
+	lastCheck time.Time
+	inFlight  bool
+}
+
+// NewTaskWatcher creates a watcher. Call Run to start it.
+func (c *Client) NewTaskWatcher(opts WatcherOptions) *TaskWatcher {
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = DefaultWatcherRequestsPerSecond
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = DefaultWatcherMinInterval
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultWatcherConcurrency
+	}
+	return &TaskWatcher{
+		c:       c,
+		opts:    opts,
+		results: make(chan WatchResult, opts.Concurrency),
+		wake:    make(chan struct{}, 1),
+		runs:    make(map[string]*watchedRun),
+	}
+}
+
+// Add starts watching runIDs. Runs already being watched are ignored.
+func (w *TaskWatcher) Add(runIDs ...string) {
+	w.mu.Lock()
+	for _, id := range runIDs {
+		if _, ok := w.runs[id]; ok {
+			continue
+		}
+		w.runs[id] = &watchedRun{}
+		w.queue = append(w.queue, id)
+	}
+	w.mu.Unlock()
+	w.signal()
+}
+
+// Remove stops watching runIDs without delivering a result for them.
+func (w *TaskWatcher) Remove(runIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range runIDs {
+		w.removeLocked(id)
+	}
+}
+
+// removeLocked forgets id. w.mu must be held.
+func (w *TaskWatcher) removeLocked(id string) {
+	if _, ok := w.runs[id]; !ok {
+		return
+	}
+	delete(w.runs, id)
+	w.queue = slices.DeleteFunc(w.queue, func(q string) bool { return q == id })
+}
+
+// Len returns the number of runs being watched.
+func (w *TaskWatcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.runs)
+}
+
+// Results returns the channel finished runs are delivered on when
+// WatcherOptions.OnResult is nil. It is closed when Run returns.
+func (w *TaskWatcher) Results() <-chan WatchResult {
+	return w.results
+}
+
+// Run schedules status checks until ctx is done, then waits for in-flight
+// checks and returns ctx's error. It must be called only once.
+func (w *TaskWatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(w.results)
+	}()
+
+	limiter := time.NewTicker(time.Duration(float64(time.Second) / w.opts.RequestsPerSecond))
+	defer limiter.Stop()
+	slots := make(chan struct{}, w.opts.Concurrency)
+
+	for {
+		id, wait := w.next(time.Now())
+		if id == "" {
+			if err := w.idle(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			w.release(id)
+			return ctx.Err()
+		case <-limiter.C:
+		}
+		select {
+		case <-ctx.Done():
+			w.release(id)
+			return ctx.Err()
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			w.check(ctx, id)
+		}()
+	}
+}
+
+// signal wakes the scheduler if it is idle.
+func (w *TaskWatcher) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// idle waits until a run is added or checked, wait elapses (if non-zero) or ctx is done.
+func (w *TaskWatcher) idle(ctx context.Context, wait time.Duration) error {
+	var timer <-chan time.Time
+	if wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		timer = t.C
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.wake:
+	case <-timer:
+	}
+	return nil
+}
+
+// next claims the first run in round-robin order that is due for a check and
+// moves it to the back of the queue. If none is due, it returns how long until
+// one will be, or zero if nothing is waiting.
+func (w *TaskWatcher) next(now time.Time) (string, time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var wait time.Duration
+	for i, id := range w.queue {
+		r := w.runs[id]
+		if r.inFlight {
+			continue
+		}
+		due := r.lastCheck.Add(w.opts.MinInterval)
+		if !r.lastCheck.IsZero() && due.After(now) {
+			if d := due.Sub(now); wait == 0 || d < wait {
+				wait = d
+			}
+			continue
+		}
+
+		r.inFlight = true
+		w.queue = append(append(w.queue[:i:i], w.queue[i+1:]...), id)
+		return id, 0
+	}
+	return "", wait
+}
+
+// release returns a claimed run to the pool without checking it.
+func (w *TaskWatcher) release(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if r, ok := w.runs[id]; ok {
+		r.inFlight = false
+	}
+}
+
+// check fetches one run's status and delivers it if the run has finished.
+// Transient request errors leave the run in the rotation.
+func (w *TaskWatcher) check(ctx context.Context, id string) {
+	task, err := w.c.GetTask(ctx, id)
+
+	var (
+		res  WatchResult
+		done bool
+	)
+	switch {
+	case err != nil:
+		res, done = WatchResult{RunID: id, Err: err}, ctx.Err() == nil && permanent(err)
+	case task.finished():
+		_, err := checkFinished(task)
+		res, done = WatchResult{RunID: id, Result: task, Err: err}, true
+	}
+
+	w.mu.Lock()
+	r, watched := w.runs[id]
+	switch {
+	case watched && done:
+		w.removeLocked(id)
+	case watched:
+		r.inFlight = false
+		r.lastCheck = time.Now()
+	}
+	w.mu.Unlock()
+	w.signal()
+	if !watched || !done {
+		return
+	}
+
+	if w.opts.OnResult != nil {
+		w.opts.OnResult(res)
+		return
+	}
+	select {
+	case w.results <- res:
+	case <-ctx.Done():
+	}
+}
+
+// permanent reports whether a status request error will not go away by retrying.
+func permanent(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500
+}
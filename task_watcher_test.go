@@ -0,0 +1,118 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTaskWatcher(t *testing.T) {
+	var mu sync.Mutex
+	checks := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/tasks/runs/")
+		mu.Lock()
+		checks[id]++
+		n := checks[id]
+		mu.Unlock()
+
+		switch {
+		case id == "run_missing":
+			w.WriteHeader(http.StatusNotFound)
+		case id == "run_failed":
+			json.NewEncoder(w).Encode(ParallelTaskResult{RunID: id, Status: TaskStatusFailed})
+		case n < 3:
+			json.NewEncoder(w).Encode(ParallelTaskResult{RunID: id, Status: TaskStatusRunning, IsActive: boolPtr(true)})
+		default:
+			json.NewEncoder(w).Encode(ParallelTaskResult{RunID: id, Status: TaskStatusCompleted})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	watcher := client.NewTaskWatcher(WatcherOptions{RequestsPerSecond: 1000, MinInterval: time.Millisecond, Concurrency: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	watcher.Add("run_ok", "run_failed", "run_removed")
+	watcher.Remove("run_removed")
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+	watcher.Add("run_missing", "run_ok")
+
+	results := make(map[string]WatchResult)
+	for len(results) < 3 {
+		select {
+		case res := <-watcher.Results():
+			results[res.RunID] = res
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for results, got %v", results)
+		}
+	}
+
+	if res := results["run_ok"]; res.Err != nil || res.Result.Status != TaskStatusCompleted {
+		t.Errorf("Expected run_ok to complete, got %+v", res)
+	}
+	var failed *TaskFailedError
+	if res := results["run_failed"]; !errors.As(res.Err, &failed) {
+		t.Errorf("Expected run_failed to report *TaskFailedError, got %+v", res)
+	}
+	if res := results["run_missing"]; !errors.Is(res.Err, ErrNotFound) {
+		t.Errorf("Expected run_missing to report ErrNotFound, got %+v", res)
+	}
+	if watcher.Len() != 0 {
+		t.Errorf("Expected no runs left, got %d", watcher.Len())
+	}
+
+	mu.Lock()
+	if checks["run_removed"] != 0 {
+		t.Errorf("Expected removed run not to be checked, got %d checks", checks["run_removed"])
+	}
+	mu.Unlock()
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from Run, got %v", err)
+	}
+	if _, open := <-watcher.Results(); open {
+		t.Errorf("Expected Results to be closed after Run returns")
+	}
+}
+
+func TestTaskWatcherRoundRobin(t *testing.T) {
+	watcher := NewClient().NewTaskWatcher(WatcherOptions{MinInterval: time.Minute})
+	watcher.Add("a", "b", "c")
+
+	now := time.Now()
+	var order []string
+	for range 3 {
+		id, _ := watcher.next(now)
+		order = append(order, id)
+	}
+	if strings.Join(order, ",") != "a,b,c" {
+		t.Errorf("Expected order a,b,c, got %v", order)
+	}
+
+	if id, _ := watcher.next(now); id != "" {
+		t.Errorf("Expected no run while all are in flight, got %s", id)
+	}
+
+	for _, id := range []string{"a", "b"} {
+		watcher.runs[id].inFlight = false
+		watcher.runs[id].lastCheck = now
+	}
+	watcher.runs["c"].inFlight = false
+	if id, wait := watcher.next(now.Add(time.Second)); id != "c" || wait != 0 {
+		t.Errorf("Expected never-checked run c to be due, got %q (wait %s)", id, wait)
+	}
+	if id, wait := watcher.next(now.Add(time.Second)); id != "" || wait != 59*time.Second {
+		t.Errorf("Expected to wait 59s for the next run, got %q (wait %s)", id, wait)
+	}
+}
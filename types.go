@@ -1,14 +1,27 @@
 // path: parallel/types.go
 package parallel
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ParallelSearchRequest defines the request body for Parallel API search.
 type ParallelSearchRequest struct {
-	Objective         string   `json:"objective"`
-	SearchQueries     []string `json:"search_queries"`
-	MaxResults        int      `json:"max_results"`
-	MaxCharsPerResult int      `json:"max_chars_per_result"`
+	Objective         string        `json:"objective"`
+	SearchQueries     []string      `json:"search_queries"`
+	MaxResults        int           `json:"max_results"`
+	MaxCharsPerResult int           `json:"max_chars_per_result"`
+	Processor         string        `json:"processor,omitempty"` // SearchProcessorBase or SearchProcessorPro
+	Mode              string        `json:"mode,omitempty"`      // SearchModeOneShot or SearchModeAgentic
+	SourcePolicy      *SourcePolicy `json:"source_policy,omitempty"`
+}
+
+// SourcePolicy restricts which sources a search may return.
+type SourcePolicy struct {
+	IncludeDomains []string `json:"include_domains,omitempty"` // allow-list of domains, e.g. "example.com"
+	ExcludeDomains []string `json:"exclude_domains,omitempty"` // deny-list of domains
+	AfterDate      string   `json:"after_date,omitempty"`      // only sources published on or after this date, as YYYY-MM-DD
 }
 
 // ParallelSearchResponse is the full response from the API.
@@ -25,11 +38,33 @@ type ParallelResult struct {
 }
 
 // ParallelExtractRequest defines the request structure for /extract.
+// ExcerptSettings and FullContentSettings, when set, are sent in place of the
+// Excerpts and FullContent flags and imply them.
 type ParallelExtractRequest struct {
-	URLs        []string `json:"urls"`
-	Objective   string   `json:"objective"`
-	Excerpts    bool     `json:"excerpts"`
-	FullContent bool     `json:"full_content"`
+	URLs                []string             `json:"urls"`
+	Objective           string               `json:"objective"`
+	Excerpts            bool                 `json:"excerpts"`
+	FullContent         bool                 `json:"full_content"`
+	ExcerptSettings     *ExcerptSettings     `json:"-"`
+	FullContentSettings *FullContentSettings `json:"-"`
+	FetchPolicy         *FetchPolicy         `json:"fetch_policy,omitempty"`
+}
+
+// ExcerptSettings configures the excerpts returned by /extract.
+type ExcerptSettings struct {
+	MaxCharsPerResult int `json:"max_chars_per_result,omitempty"`
+}
+
+// FullContentSettings configures the full page content returned by /extract.
+type FullContentSettings struct {
+	MaxCharsPerResult int `json:"max_chars_per_result,omitempty"`
+}
+
+// FetchPolicy controls when /extract fetches live content instead of using its cache.
+type FetchPolicy struct {
+	MaxAgeSeconds        int  `json:"max_age_seconds,omitempty"`        // refetch cached content older than this
+	TimeoutSeconds       int  `json:"timeout_seconds,omitempty"`        // time limit for live fetches
+	DisableCacheFallback bool `json:"disable_cache_fallback,omitempty"` // fail instead of serving stale content when a fetch fails
 }
 
 // ParallelExtractResponse represents the API’s extraction response.
@@ -49,13 +84,38 @@ type ParallelExtract struct {
 
 // ParallelAPIError captures any per-request errors.
 type ParallelAPIError struct {
-	Message string `json:"message"`
+	URL            string `json:"url,omitempty"`
+	ErrorType      string `json:"error_type,omitempty"`
+	HTTPStatusCode int    `json:"http_status_code,omitempty"`
+	Message        string `json:"message"`
 }
 
 // ParallelTaskRequest defines the request structure for /tasks/runs.
 type ParallelTaskRequest struct {
-	Input     string `json:"input"`
-	Processor string `json:"processor"`
+	Input     any               `json:"input"` // text prompt, or structured JSON input
+	Processor string            `json:"processor"`
+	TaskSpec  *ParallelTaskSpec `json:"task_spec,omitempty"`
+	Metadata  map[string]any    `json:"metadata,omitempty"`
+	Webhook   *ParallelWebhook  `json:"webhook,omitempty"`
+}
+
+// ParallelTaskSpec describes the expected input and output of a task.
+type ParallelTaskSpec struct {
+	InputSchema  *ParallelTaskSchema `json:"input_schema,omitempty"`
+	OutputSchema *ParallelTaskSchema `json:"output_schema,omitempty"`
+}
+
+// ParallelTaskSchema is either a JSON schema or a free-text description.
+type ParallelTaskSchema struct {
+	Type        string         `json:"type"` // "json", "text" or "auto"
+	JSONSchema  map[string]any `json:"json_schema,omitempty"`
+	Description string         `json:"description,omitempty"`
+}
+
+// ParallelWebhook configures a webhook notified about a task run.
+type ParallelWebhook struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types,omitempty"` // e.g. WebhookEventTaskRunStatus
 }
 
 // ParallelTaskResponse represents the structured output from Parallel’s task engine.
@@ -75,6 +135,8 @@ type ParallelTaskResponse struct {
 }
 
 // ParallelTaskContent mirrors the “content” structure from your example.
+// For other output shapes, use RunTaskTyped, GetTaskTyped or DecodeTaskRun
+// with your own struct.
 type ParallelTaskContent struct {
 	MarketSizeAndForecast struct {
 		CAGR                string `json:"cagr"`
@@ -133,17 +195,17 @@ type ParallelBasisCitation struct {
 // ParallelTaskResult represents a **detailed** task status or completed output.
 // This is what GetTask() and PollUntilComplete() return.
 type ParallelTaskResult struct {
-	RunID       string    `json:"run_id"`
-	Status      string    `json:"status"`
-	IsActive    bool      `json:"is_active"`
-	Processor   string    `json:"processor"`
-	Output      any       `json:"output"`       // raw JSON output from Parallel
-	Error       any       `json:"error"`        // may contain error details or null
-	Warnings    any       `json:"warnings"`     // optional warnings
-	Metadata    any       `json:"metadata"`     // optional metadata
-	TaskGroupID string    `json:"taskgroup_id"` // may be null
-	CreatedAt   time.Time `json:"created_at"`
-	ModifiedAt  time.Time `json:"modified_at"`
+	RunID       string             `json:"run_id"`
+	Status      TaskStatus         `json:"status"`
+	IsActive    *bool              `json:"is_active"` // nil if the server did not report it
+	Processor   string             `json:"processor"`
+	Output      any                `json:"output"`       // raw JSON output from Parallel
+	Error       *ParallelTaskError `json:"error"`        // error details, or nil
+	Warnings    any                `json:"warnings"`     // optional warnings
+	Metadata    any                `json:"metadata"`     // optional metadata
+	TaskGroupID string             `json:"taskgroup_id"` // may be null
+	CreatedAt   time.Time          `json:"created_at"`
+	ModifiedAt  time.Time          `json:"modified_at"`
 }
 
 // ParallelChatRequest defines a chat completion request.
@@ -188,3 +250,86 @@ type ParallelChatChoice struct {
 	Message      ParallelChatMessage `json:"message"`
 	FinishReason string              `json:"finish_reason"`
 }
+
+// ParallelChatChunk is one incremental event of a streamed chat completion.
+type ParallelChatChunk struct {
+	ID      string                    `json:"id"`
+	Object  string                    `json:"object"`
+	Model   string                    `json:"model"`
+	Created int64                     `json:"created"`
+	Choices []ParallelChatChunkChoice `json:"choices"`
+	Usage   map[string]any            `json:"usage,omitempty"`
+}
+
+// ParallelChatChunkChoice holds the delta for one choice in a streamed chunk.
+type ParallelChatChunkChoice struct {
+	Index        int               `json:"index"`
+	Delta        ParallelChatDelta `json:"delta"`
+	FinishReason string            `json:"finish_reason"`
+}
+
+// ParallelChatDelta is the incremental message content in a streamed chunk.
+type ParallelChatDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// ParallelTaskGroupRequest defines the request body for creating a task group.
+type ParallelTaskGroupRequest struct {
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ParallelTaskGroup describes a task group and its aggregate status.
+type ParallelTaskGroup struct {
+	TaskGroupID string                  `json:"taskgroup_id"`
+	Status      ParallelTaskGroupStatus `json:"status"`
+	Metadata    map[string]any          `json:"metadata"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+// ParallelTaskGroupStatus aggregates the status of all runs in a task group.
+type ParallelTaskGroupStatus struct {
+	NumTaskRuns         int            `json:"num_task_runs"`
+	TaskRunStatusCounts map[string]int `json:"task_run_status_counts"` // e.g. {"running": 3, "completed": 7}
+	IsActive            bool           `json:"is_active"`
+	StatusMessage       string         `json:"status_message"`
+	ModifiedAt          time.Time      `json:"modified_at"`
+}
+
+// ParallelTaskGroupRunsRequest defines the request body for adding runs to a task group.
+type ParallelTaskGroupRunsRequest struct {
+	Inputs []ParallelTaskRequest `json:"inputs"`
+}
+
+// ParallelTaskGroupRunsResponse lists the runs created by AddTaskGroupRuns.
+type ParallelTaskGroupRunsResponse struct {
+	RunIDs []string                `json:"run_ids"`
+	Status ParallelTaskGroupStatus `json:"status"`
+}
+
+// ParallelTaskGroupRunsPage is one page of a task group's runs.
+type ParallelTaskGroupRunsPage struct {
+	Runs       []ParallelTaskResult `json:"runs"`
+	NextCursor string               `json:"next_cursor"` // empty on the last page
+}
+
+// ParallelTaskRunResult is the final result of a task run, as returned by GetTaskResult.
+type ParallelTaskRunResult struct {
+	Run    ParallelTaskResult `json:"run"`
+	Output ParallelTaskOutput `json:"output"`
+}
+
+// ParallelTaskOutput holds a task run's output content with its basis and warnings.
+type ParallelTaskOutput struct {
+	Type     string            `json:"type"`    // "json" or "text"
+	Content  json.RawMessage   `json:"content"` // decode with Decode or GetTaskResultTyped
+	Basis    []ParallelBasis   `json:"basis"`
+	Warnings []ParallelWarning `json:"warnings"`
+}
+
+// ParallelWarning is a non-fatal issue reported for a task run.
+type ParallelWarning struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Detail  any    `json:"detail,omitempty"`
+}
@@ -0,0 +1,278 @@
+// path: parallel/webhook.go
+package parallel
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookBetaTag is the parallel-beta value required when a task run sets a webhook.
+const WebhookBetaTag = "webhook-2025-08-12"
+
+// Webhook event types reported in WebhookEvent.Type.
+const (
+	WebhookEventTaskRunStatus = "task_run.status"
+)
+
+// Headers carrying the webhook signature, in the Standard Webhooks format.
+const (
+	WebhookIDHeader        = "webhook-id"
+	WebhookTimestampHeader = "webhook-timestamp"
+	WebhookSignatureHeader = "webhook-signature"
+)
+
+// DefaultWebhookTolerance is the maximum accepted age of a webhook timestamp.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// maxWebhookBody bounds the size of an accepted webhook payload.
+const maxWebhookBody = 1 << 20
+
+// WebhookEvent is a verified webhook delivery.
+type WebhookEvent struct {
+	ID        string              `json:"-"` // webhook-id header, unique per delivery
+	Type      string              `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	Data      json.RawMessage     `json:"data"`
+	Run       *ParallelTaskResult `json:"-"` // decoded Data for task_run.* events
+}
+
+// WebhookFunc handles a verified webhook event. Returning an error responds
+// with 500 so the sender retries the delivery.
+type WebhookFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookOption configures a WebhookHandler.
+type WebhookOption func(*WebhookHandler)
+
+// WithWebhookTolerance sets the maximum accepted age of a delivery's timestamp.
+// Replayed deliveries are remembered for the same window.
+func WithWebhookTolerance(d time.Duration) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.tolerance = d
+	}
+}
+
+// WithTaskRunCallback sets the callback for task run status events.
+func WithTaskRunCallback(fn WebhookFunc) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.onTaskRun = fn
+	}
+}
+
+// WithWebhookCallback sets the callback for event types without a dedicated callback.
+func WithWebhookCallback(fn WebhookFunc) WebhookOption {
+	return func(h *WebhookHandler) {
+		h.onEvent = fn
+	}
+}
+
+// WebhookHandler is an http.Handler that receives Parallel webhooks. It
+// verifies the HMAC signature and timestamp, decodes the payload and
+// dispatches it to the configured callbacks. A delivery that was already
+// handled is acknowledged again without calling the callbacks, so a sender
+// retrying after a lost response stops retrying.
+type WebhookHandler struct {
+	key       []byte
+	tolerance time.Duration
+	now       func() time.Time
+	onTaskRun WebhookFunc
+	onEvent   WebhookFunc
+
+	mu    sync.Mutex
+	seen  map[string]*webhookDelivery
+	order []webhookClaim // claims in time order, for expiry
+}
+
+// webhookDelivery is the state of a claimed delivery.
+type webhookDelivery struct {
+	claimed time.Time
+	done    bool // the callback succeeded
+}
+
+type webhookClaim struct {
+	id string
+	at time.Time
+}
+
+// NewWebhookHandler creates a handler that verifies deliveries with secret.
+// A Standard Webhooks secret of the form "whsec_<base64>" is decoded first;
+// any other secret is used as is.
+func NewWebhookHandler(secret string, opts ...WebhookOption) *WebhookHandler {
+	h := &WebhookHandler{
+		key:       webhookKey(secret),
+		tolerance: DefaultWebhookTolerance,
+		now:       time.Now,
+		seen:      make(map[string]*webhookDelivery),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+
+	id := r.Header.Get(WebhookIDHeader)
+	if err := h.verify(id, r.Header.Get(WebhookTimestampHeader), r.Header.Get(WebhookSignatureHeader), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "decode payload", http.StatusBadRequest)
+		return
+	}
+	event.ID = id
+	if strings.HasPrefix(event.Type, "task_run.") {
+		var run ParallelTaskResult
+		if err := json.Unmarshal(event.Data, &run); err != nil {
+			http.Error(w, "decode payload", http.StatusBadRequest)
+			return
+		}
+		event.Run = &run
+	}
+
+	switch h.claim(id) {
+	case deliveryDone:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case deliveryInProgress:
+		http.Error(w, "delivery in progress", http.StatusConflict)
+		return
+	}
+
+	fn := h.onEvent
+	if event.Type == WebhookEventTaskRunStatus && h.onTaskRun != nil {
+		fn = h.onTaskRun
+	}
+	if fn != nil {
+		if err := fn(r.Context(), &event); err != nil {
+			h.release(id)
+			http.Error(w, "handler failed", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.complete(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the timestamp window and the signature of a delivery.
+func (h *WebhookHandler) verify(id, timestamp, signatures string, body []byte) error {
+	if id == "" || timestamp == "" || signatures == "" {
+		return errors.New("missing webhook signature headers")
+	}
+
+	secs, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid webhook timestamp")
+	}
+	age := h.now().Sub(time.Unix(secs, 0))
+	if age > h.tolerance || age < -h.tolerance {
+		return errors.New("webhook timestamp outside tolerance")
+	}
+
+	expected := signWebhook(h.key, id, time.Unix(secs, 0), body)
+	for _, sig := range strings.Fields(signatures) {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return errors.New("invalid webhook signature")
+}
+
+// Claim results for a delivery ID.
+const (
+	deliveryNew        = iota // first delivery; the caller must complete or release it
+	deliveryInProgress        // another request is handling it
+	deliveryDone              // already handled successfully
+)
+
+// claim records id as being delivered, reporting whether it was seen before.
+// Claims older than twice the tolerance are expired, since their timestamps
+// can no longer pass verification.
+func (h *WebhookHandler) claim(id string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	for len(h.order) > 0 && now.Sub(h.order[0].at) > 2*h.tolerance {
+		old := h.order[0]
+		h.order = h.order[1:]
+		if d, ok := h.seen[old.id]; ok && d.claimed.Equal(old.at) {
+			delete(h.seen, old.id)
+		}
+	}
+	if d, ok := h.seen[id]; ok {
+		if d.done {
+			return deliveryDone
+		}
+		return deliveryInProgress
+	}
+	h.seen[id] = &webhookDelivery{claimed: now}
+	h.order = append(h.order, webhookClaim{id: id, at: now})
+	return deliveryNew
+}
+
+// complete marks id as handled successfully.
+func (h *WebhookHandler) complete(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if d, ok := h.seen[id]; ok {
+		d.done = true
+	}
+}
+
+// release forgets id so a failed delivery can be retried.
+func (h *WebhookHandler) release(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, id)
+}
+
+// SignWebhook returns the webhook-signature header value for a delivery.
+// It is what Parallel computes when sending, and is useful for signing
+// payloads in tests. Secrets are interpreted as by NewWebhookHandler.
+func SignWebhook(secret, id string, timestamp time.Time, body []byte) string {
+	return signWebhook(webhookKey(secret), id, timestamp, body)
+}
+
+func signWebhook(key []byte, id string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s.%d.", id, timestamp.Unix())
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// webhookKey returns the HMAC key for secret, decoding the base64 part of a
+// Standard Webhooks "whsec_" secret.
+func webhookKey(secret string) []byte {
+	if encoded, ok := strings.CutPrefix(secret, "whsec_"); ok {
+		if key, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+			return key
+		}
+	}
+	return []byte(secret)
+}
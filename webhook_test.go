@@ -0,0 +1,139 @@
+package parallel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "test-webhook-secret"
+
+	var received []*WebhookEvent
+	handler := NewWebhookHandler(secret, WithTaskRunCallback(func(ctx context.Context, event *WebhookEvent) error {
+		received = append(received, event)
+		return nil
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := []byte(`{"type":"task_run.status","timestamp":"2025-10-10T12:00:00Z","data":{"run_id":"test-run-id","status":"completed"}}`)
+	deliver := func(id string, ts time.Time, signature string) int {
+		req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(body))
+		req.Header.Set(WebhookIDHeader, id)
+		req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+		req.Header.Set(WebhookSignatureHeader, signature)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name     string
+		id       string
+		ts       time.Time
+		sig      string
+		expected int
+	}{
+		{"valid delivery", "msg_1", now, SignWebhook(secret, "msg_1", now, body), http.StatusNoContent},
+		{"replayed delivery", "msg_1", now, SignWebhook(secret, "msg_1", now, body), http.StatusNoContent},
+		{"wrong secret", "msg_2", now, SignWebhook("other-secret", "msg_2", now, body), http.StatusUnauthorized},
+		{"stale timestamp", "msg_3", now.Add(-time.Hour), SignWebhook(secret, "msg_3", now.Add(-time.Hour), body), http.StatusUnauthorized},
+		{"rotated secrets", "msg_4", now, "v1,bogus " + SignWebhook(secret, "msg_4", now, body), http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := deliver(tt.id, tt.ts, tt.sig); status != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, status)
+			}
+		})
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 dispatched events, got %d", len(received))
+	}
+	if received[0].ID != "msg_1" || received[0].Run == nil || received[0].Run.Status != "completed" {
+		t.Errorf("Expected decoded completed run for msg_1, got %+v", received[0])
+	}
+}
+
+func TestWebhookStandardSecret(t *testing.T) {
+	key := []byte("raw signing key")
+	secret := "whsec_" + base64.StdEncoding.EncodeToString(key)
+	handler := NewWebhookHandler(secret)
+
+	body := []byte(`{"type":"task_run.status","data":{"run_id":"test-run-id","status":"running"}}`)
+	now := time.Now()
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s.%d.%s", "msg_1", now.Unix(), body)
+	signature := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if signature != SignWebhook(secret, "msg_1", now, body) {
+		t.Errorf("Expected SignWebhook to sign with the decoded key")
+	}
+	if err := handler.verify("msg_1", strconv.FormatInt(now.Unix(), 10), signature, body); err != nil {
+		t.Errorf("Expected signature made with the decoded key to verify, got %v", err)
+	}
+}
+
+func TestWebhookClaimExpiry(t *testing.T) {
+	now := time.Now()
+	handler := NewWebhookHandler("secret", WithWebhookTolerance(time.Minute))
+	handler.now = func() time.Time { return now }
+
+	if handler.claim("msg_1") != deliveryNew {
+		t.Fatalf("Expected first claim to be new")
+	}
+	if handler.claim("msg_1") != deliveryInProgress {
+		t.Errorf("Expected claim of an unfinished delivery to be in progress")
+	}
+	handler.complete("msg_1")
+	if handler.claim("msg_1") != deliveryDone {
+		t.Errorf("Expected claim of a handled delivery to be done")
+	}
+
+	now = now.Add(3 * time.Minute)
+	handler.claim("msg_2")
+	if len(handler.seen) != 1 || len(handler.order) != 1 {
+		t.Errorf("Expected expired claims to be dropped, got %d seen and %d queued", len(handler.seen), len(handler.order))
+	}
+}
+
+func TestRunTaskWebhook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("parallel-beta"), WebhookBetaTag) {
+			t.Errorf("Expected parallel-beta header to contain %s, got %s", WebhookBetaTag, r.Header.Get("parallel-beta"))
+		}
+		var req ParallelTaskRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Webhook == nil || req.Webhook.URL != "https://example.com/hook" {
+			t.Errorf("Expected webhook URL 'https://example.com/hook', got %+v", req.Webhook)
+		}
+		json.NewEncoder(w).Encode(ParallelTaskResponse{})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	_, err := client.RunTask(context.Background(), ParallelTaskRequest{
+		Input:   "test input",
+		Webhook: &ParallelWebhook{URL: "https://example.com/hook", EventTypes: []string{WebhookEventTaskRunStatus}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}